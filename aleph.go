@@ -0,0 +1,289 @@
+package marc21
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// alephLineMinLength is the length of the fixed part of a line in Aleph
+// sequential format: system number, tag, indicators and format.
+const alephLineMinLength = 18
+
+// alephLine is a single parsed line in Aleph sequential format.
+type alephLine struct {
+	sysno string
+	tag   string
+	ind1  byte
+	ind2  byte
+	data  string
+	n     int
+}
+
+// parseAlephLine parses a line like "000012345 24510 L $$aTitle".
+func parseAlephLine(s string) (alephLine, error) {
+	if len(s) < alephLineMinLength || s[9] != ' ' || s[15] != ' ' || s[17] != ' ' {
+		return alephLine{}, errors.New("invalid line, expected sysno, tag, indicators and format")
+	}
+	return alephLine{
+		sysno: s[0:9],
+		tag:   s[10:13],
+		ind1:  s[13],
+		ind2:  s[14],
+		data:  s[alephLineMinLength:],
+	}, nil
+}
+
+// isAlephControlField is the default rule for tags, that have no indicators
+// and subfields in Aleph sequential format.
+func isAlephControlField(tag string) bool {
+	return tag == "FMT" || strings.HasPrefix(tag, "00")
+}
+
+// parseAlephSubfields parses subfields delimited by $$.
+func parseAlephSubfields(s string) ([]*SubField, error) {
+	if !strings.HasPrefix(s, "$$") {
+		return nil, errors.New("data does not start with a subfield delimiter")
+	}
+	parts := strings.Split(s[2:], "$$")
+	subfields := make([]*SubField, 0, len(parts))
+	for _, p := range parts {
+		if len(p) == 0 {
+			return nil, errors.New("subfield without code")
+		}
+		subfields = append(subfields, &SubField{Code: p[0], Value: p[1:]})
+	}
+	return subfields, nil
+}
+
+// AlephReader reads records in Aleph sequential format, as exported by
+// Ex Libris Aleph, with one line per field:
+//
+//	000012345 FMT   L BK
+//	000012345 LDR   L 00000nam^^2200000^^^4500
+//	000012345 001   L 12345
+//	000012345 24510 L $$aTitle$$cAuthor
+//	000012345 CAT   L $$aBATCH$$b00$$c20170101
+//
+// Consecutive lines with the same system number form a record. FMT and the
+// 00X fields are read as control fields by default, all other tags,
+// including local tags, as data fields. Carets stand for blanks in the leader and control
+// fields.
+type AlephReader struct {
+	// IsControlField, if not nil, decides which tags are read as control
+	// fields. By default, FMT and tags starting with "00" are.
+	IsControlField func(tag string) bool
+
+	br     *bufio.Reader
+	line   int
+	peeked *alephLine
+	sysno  string
+	record *Record
+	err    error
+	count  int
+}
+
+// NewAlephReader returns a new AlephReader that reads from r.
+func NewAlephReader(r io.Reader) *AlephReader {
+	return &AlephReader{br: bufio.NewReader(r)}
+}
+
+// readLine returns the next non-blank line.
+func (r *AlephReader) readLine() (alephLine, error) {
+	if r.peeked != nil {
+		line := *r.peeked
+		r.peeked = nil
+		return line, nil
+	}
+	for {
+		s, err := r.br.ReadString('\n')
+		if err == io.EOF && len(s) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return alephLine{}, err
+		}
+		r.line++
+		s = strings.TrimRight(s, "\r\n")
+		if isBlank(s) {
+			continue
+		}
+		line, err := parseAlephLine(s)
+		if err != nil {
+			return line, fmt.Errorf("line %d: %s", r.line, err)
+		}
+		line.n = r.line
+		return line, nil
+	}
+}
+
+// Next returns the next record. It returns io.EOF, if there are no more
+// records.
+func (r *AlephReader) Next() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	isControl := r.IsControlField
+	if isControl == nil {
+		isControl = isAlephControlField
+	}
+	var record *Record
+	var sysno string
+	for {
+		line, err := r.readLine()
+		if err == io.EOF && record != nil {
+			break
+		}
+		if err != nil {
+			r.err = err
+			return nil, err
+		}
+		if record != nil && line.sysno != sysno {
+			r.peeked = &line
+			break
+		}
+		if record == nil {
+			record, sysno = &Record{}, line.sysno
+		}
+		switch {
+		case line.tag == "LDR":
+			leader, err := parseLeaderString(strings.Replace(line.data, "^", " ", -1))
+			if err != nil {
+				r.err = fmt.Errorf("line %d: %s", line.n, err)
+				return nil, r.err
+			}
+			record.Leader = leader
+		case isControl(line.tag):
+			record.AddField(&ControlField{
+				Tag:  line.tag,
+				Data: strings.Replace(line.data, "^", " ", -1),
+			})
+		default:
+			subfields, err := parseAlephSubfields(line.data)
+			if err != nil {
+				r.err = fmt.Errorf("line %d: data field %s: %s", line.n, line.tag, err)
+				return nil, r.err
+			}
+			record.AddField(&DataField{
+				Tag:       line.tag,
+				Ind1:      line.ind1,
+				Ind2:      line.ind2,
+				SubFields: subfields,
+			})
+		}
+	}
+	if record.Leader == nil {
+		record.Leader = defaultLeader()
+	}
+	r.count++
+	r.sysno = sysno
+	r.record = record
+	return record, nil
+}
+
+// Scan advances the reader to the next record, which will then be available
+// through the Record method. It returns false when the input is exhausted or
+// an error occurred.
+func (r *AlephReader) Scan() bool {
+	_, err := r.Next()
+	return err == nil
+}
+
+// Record returns the most recent record read by a call to Scan or Next.
+func (r *AlephReader) Record() *Record {
+	return r.record
+}
+
+// SystemNumber returns the system number of the most recent record.
+func (r *AlephReader) SystemNumber() string {
+	return r.sysno
+}
+
+// Err returns the first non-EOF error that was encountered by the reader.
+func (r *AlephReader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Count returns the number of records read so far.
+func (r *AlephReader) Count() int {
+	return r.count
+}
+
+// AlephWriter writes records in Aleph sequential format.
+type AlephWriter struct {
+	// SystemNumber, if not nil, returns the system number of a record. By
+	// default, records are numbered sequentially, starting at 1. Numbers are
+	// padded with zeros to nine digits.
+	SystemNumber func(record *Record) string
+	// IsControlField, if not nil, is checked against the type of each
+	// field, so that records can be read back with the same predicate.
+	IsControlField func(tag string) bool
+
+	w     io.Writer
+	count int
+}
+
+// NewAlephWriter returns a new AlephWriter that writes to w.
+func NewAlephWriter(w io.Writer) *AlephWriter {
+	return &AlephWriter{w: w}
+}
+
+// Write writes a single record. The leader is written after any leading FMT
+// fields.
+func (w *AlephWriter) Write(record *Record) error {
+	w.count++
+	sysno := fmt.Sprintf("%d", w.count)
+	if w.SystemNumber != nil {
+		sysno = w.SystemNumber(record)
+	}
+	if len(sysno) > 9 {
+		return fmt.Errorf("system number %q longer than 9 characters", sysno)
+	}
+	sysno = strings.Repeat("0", 9-len(sysno)) + sysno
+
+	leader := record.Leader
+	if leader == nil {
+		leader = defaultLeader()
+	}
+	var buf strings.Builder
+	writeLeader := func() {
+		fmt.Fprintf(&buf, "%s LDR   L %s\n", sysno, strings.Replace(leader.String(), " ", "^", -1))
+		leader = nil
+	}
+	for _, f := range record.Fields {
+		tag := f.GetTag()
+		if len(tag) != 3 {
+			return fmt.Errorf("invalid tag %q, expected 3 bytes", tag)
+		}
+		if err := checkFieldKind(f, w.IsControlField); err != nil {
+			return err
+		}
+		if leader != nil && tag != "FMT" {
+			writeLeader()
+		}
+		switch field := f.(type) {
+		case *ControlField:
+			fmt.Fprintf(&buf, "%s %s   L %s\n", sysno, tag, strings.Replace(field.Data, " ", "^", -1))
+		case *DataField:
+			fmt.Fprintf(&buf, "%s %s%c%c L ", sysno, tag, field.Ind1, field.Ind2)
+			for _, sf := range field.SubFields {
+				buf.WriteString("$$")
+				buf.WriteByte(sf.Code)
+				buf.WriteString(sf.Value)
+			}
+			buf.WriteByte('\n')
+		default:
+			return fmt.Errorf("unsupported field type %T", f)
+		}
+	}
+	if leader != nil {
+		writeLeader()
+	}
+	_, err := io.WriteString(w.w, buf.String())
+	return err
+}
@@ -0,0 +1,92 @@
+package marc21
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const alephDoc = `000000001 FMT   L BK
+000000001 LDR   L 00000nam^^2200000^a^4500
+000000001 001   L 12345
+000000001 008   L 920219s1993^^^^caua
+000000001 24510 L $$aArithmetic /$$cCarl Sandburg.
+000000001 650 0 L $$aArithmetic$$xJuvenile poetry.
+000000001 CAT   L $$aBATCH$$b00$$c20170101
+000000002 FMT   L BK
+000000002 LDR   L 00000nam^^2200000^a^4500
+000000002 24500 L $$aSecond
+`
+
+func TestAlephReader(t *testing.T) {
+	r := NewAlephReader(strings.NewReader(alephDoc))
+	var records []*Record
+	var sysnos []string
+	for r.Scan() {
+		records = append(records, r.Record())
+		sysnos = append(sysnos, r.SystemNumber())
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	if len(records) != 2 {
+		t.Fatalf("records, got %d, want 2", len(records))
+	}
+	if strings.Join(sysnos, ",") != "000000001,000000002" {
+		t.Errorf("system numbers, got %v", sysnos)
+	}
+	first := records[0]
+	if s := first.Leader.String(); s != "00000nam  2200000 a 4500" {
+		t.Errorf("leader, got %q", s)
+	}
+	if s := first.GetFields("FMT")[0].(*ControlField).Data; s != "BK" {
+		t.Errorf("FMT, got %q, want BK", s)
+	}
+	if s := first.GetFields("008")[0].(*ControlField).Data; s != "920219s1993    caua" {
+		t.Errorf("008, got %q", s)
+	}
+	df := first.GetFields("650")[0].(*DataField)
+	if df.Ind1 != ' ' || df.Ind2 != '0' {
+		t.Errorf("indicators, got %q %q, want ' ' '0'", df.Ind1, df.Ind2)
+	}
+	if v := first.GetSubFields("CAT", 'c'); len(v) != 1 || v[0].Value != "20170101" {
+		t.Errorf("CAT $c, got %v", v)
+	}
+	if v := records[1].GetSubFields("245", 'a'); len(v) != 1 || v[0].Value != "Second" {
+		t.Errorf("245 $a, got %v", v)
+	}
+}
+
+func TestAlephReaderError(t *testing.T) {
+	var cases = []string{
+		"000000001 LDR L short\n",
+		"000000001 24510 L Title\n",
+		"000000001 24510 L $$aTitle$$\n",
+	}
+	for _, doc := range cases {
+		r := NewAlephReader(strings.NewReader(doc))
+		if r.Scan() {
+			t.Errorf("Scan(%q), got true, want false", doc)
+		}
+		if r.Err() == nil || !strings.HasPrefix(r.Err().Error(), "line 1:") {
+			t.Errorf("Scan(%q), got %v, want error on line 1", doc, r.Err())
+		}
+	}
+}
+
+func TestAlephWriter(t *testing.T) {
+	r := NewAlephReader(strings.NewReader(alephDoc))
+	var buf bytes.Buffer
+	w := NewAlephWriter(&buf)
+	for r.Scan() {
+		if err := w.Write(r.Record()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	if buf.String() != alephDoc {
+		t.Errorf("got %q, want %q", buf.String(), alephDoc)
+	}
+}
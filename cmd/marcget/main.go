@@ -0,0 +1,137 @@
+// marcget fetches records from a binary MARC file by key, using an index
+// written by marcindex.
+//
+// Keys are given as arguments or, if there are none, read from standard
+// input, one per line. Records are written as binary MARC, unchanged from
+// the file (default), MARCXML, MARC Breaker text or MARC-in-JSON. Keys that
+// are not found are reported on standard error.
+//
+//	$ marcget -f mrk records.mrc ocm12345 ocm67890
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/miku/marc21"
+	"github.com/miku/marc21/index"
+)
+
+// recordWriter is implemented by the record writers of the marc21 package.
+type recordWriter interface {
+	Write(record *marc21.Record) error
+}
+
+func main() {
+	indexFile := flag.String("i", "", "index file, defaults to the input file with an .idx extension")
+	format := flag.String("f", "marc", "output format: marc, xml, mrk or json")
+	iso2709 := flag.Bool("iso2709", false, "accept other ISO 2709 formats, like UNIMARC, honouring the leader entry map")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		log.Fatal("usage: marcget [-i INDEX] [-f FORMAT] FILE [KEY ...]")
+	}
+	filename := *indexFile
+	if filename == "" {
+		filename = flag.Arg(0) + ".idx"
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ix, err := index.ReadIndex(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("%s: %s", filename, err)
+	}
+
+	data, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer data.Close()
+	r := index.NewReader(data, ix)
+	r.ISO2709 = *iso2709
+
+	bw := bufio.NewWriter(os.Stdout)
+
+	var (
+		w  recordWriter
+		xw *marc21.XMLWriter
+	)
+	// finish ends the output, keeping the records written so far.
+	finish := func() error {
+		if xw != nil {
+			if err := xw.Close(); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	}
+	fatal := func(err error) {
+		finish()
+		log.Fatal(err)
+	}
+	switch *format {
+	case "marc":
+	case "xml":
+		xw = marc21.NewXMLWriter(bw)
+		w = xw
+	case "mrk":
+		w = marc21.NewTextWriter(bw)
+	case "json":
+		w = marc21.NewJSONWriter(bw)
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	keys := flag.Args()[1:]
+	if len(keys) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if key := strings.TrimSpace(scanner.Text()); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	var missing int
+	for _, key := range keys {
+		entries := ix.Lookup(key)
+		if len(entries) == 0 {
+			log.Printf("%s: not found", key)
+			missing++
+			continue
+		}
+		for _, e := range entries {
+			if w == nil {
+				b, err := r.Raw(e)
+				if err != nil {
+					fatal(err)
+				}
+				if _, err := bw.Write(b); err != nil {
+					fatal(err)
+				}
+				continue
+			}
+			record, err := r.Read(e)
+			if err != nil {
+				fatal(err)
+			}
+			if err := w.Write(record); err != nil {
+				fatal(err)
+			}
+		}
+	}
+	if err := finish(); err != nil {
+		log.Fatal(err)
+	}
+	if missing > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+// marcindex writes an offset index for a binary MARC file, so that records
+// can be fetched by key with marcget.
+//
+// Records are indexed by their identifier (001) and, with -k, by the values
+// of other subfields, given as tag and code. The index is written next to
+// the file, with an .idx extension, unless -o is given.
+//
+//	$ marcindex -k 035a,020a records.mrc
+//	$ marcget records.mrc ocm12345
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/miku/marc21"
+	"github.com/miku/marc21/index"
+)
+
+func main() {
+	output := flag.String("o", "", "index file, defaults to the input file with an .idx extension")
+	keys := flag.String("k", "", "comma separated list of subfields to index in addition to 001, e.g. 035a,020a")
+	iso2709 := flag.Bool("iso2709", false, "accept other ISO 2709 formats, like UNIMARC, honouring the leader entry map")
+	verbose := flag.Bool("v", false, "report the number of entries")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: marcindex [-k 035a,...] [-o FILE] FILE")
+	}
+	funcs := []index.KeyFunc{index.Identifier}
+	if *keys != "" {
+		for _, k := range strings.Split(*keys, ",") {
+			if len(k) != 4 {
+				log.Fatalf("invalid key %q, expected tag and subfield code, e.g. 035a", k)
+			}
+			funcs = append(funcs, index.SubField(k[:3], k[3]))
+		}
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	r := marc21.NewReader(f)
+	r.ISO2709 = *iso2709
+	ix, err := index.BuildReader(r, funcs...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filename := *output
+	if filename == "" {
+		filename = flag.Arg(0) + ".idx"
+	}
+	out, err := os.Create(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := ix.WriteTo(out); err != nil {
+		log.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if *verbose {
+		log.Printf("%d records, %d entries written to %s", r.Count(), ix.Len(), filename)
+	}
+}
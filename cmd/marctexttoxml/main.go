@@ -37,21 +37,16 @@ package main
 
 import (
 	"bufio"
-	"bytes"
+	"flag"
 	"io"
 	"log"
 	"os"
-	"regexp"
-	"sync"
+	"strings"
 
 	"github.com/miku/marc21"
 )
 
-var (
-	declaration     = `<?xml version="1.0" encoding="utf-8" ?>`
-	fieldPattern    = regexp.MustCompile(`(^=[0-9][0-9][0-9]|^=LDR)`)
-	subfieldPattern = regexp.MustCompile(`([$][a-z0-9])(.*?)`)
-)
+var declaration = `<?xml version="1.0" encoding="utf-8" ?>`
 
 // stickyErrWriter keeps an error around, so you can *occasionally* check if an
 // error occured.
@@ -71,141 +66,40 @@ func (sew stickyErrWriter) Write(p []byte) (n int, err error) {
 	return
 }
 
-// parseControlField parses bytes containing a control field.
-func parseControlField(b []byte) (*marc21.ControlField, error) {
-	return &marc21.ControlField{
-		Tag:  string(b[1:4]),
-		Data: string(b[6:]),
-	}, nil
-}
-
-// decodeWhitespace decodes marc maker/breaker whitespace encoding.
-func decodeWhitespace(b byte) byte {
-	if b == 92 {
-		return ' '
-	}
-	return b
-}
-
-// parseSubfields parses bytes only containing subfield information.
-func parseSubfields(b []byte) (subfields []*marc21.SubField) {
-	if len(b) == 0 {
-		return
-	}
-	indices := subfieldPattern.FindAllIndex(b, -1)
-	for i, index := range indices {
-		if i < len(indices)-1 {
-			subfields = append(subfields, &marc21.SubField{
-				Code:  b[index[1]-1],
-				Value: string(b[index[1]:indices[i+1][0]]),
-			})
-		} else {
-			subfields = append(subfields, &marc21.SubField{
-				Code:  b[index[1]-1],
-				Value: string(b[index[1]:]),
-			})
-		}
-	}
-	return
-}
-
-// parseDataField parses a line containing a data field.
-func parseDataField(b []byte) (*marc21.DataField, error) {
-	field := &marc21.DataField{
-		Tag:       string(b[1:4]),
-		Ind1:      decodeWhitespace(b[6]),
-		Ind2:      decodeWhitespace(b[7]),
-		SubFields: parseSubfields(b[8:]),
-	}
-	return field, nil
-}
+func main() {
+	controlTags := flag.String("control-tags", "", "comma separated list of tags to read as control fields, e.g. 001,005,008,SYS")
+	flag.Parse()
 
-// parseField dispatches parsing for control and datafields.
-func parseField(b []byte) (marc21.Field, error) {
-	switch string(b[1:4]) {
-	case "001", "002", "003", "004", "005", "006", "007", "008", "009":
-		return parseControlField(b)
-	default:
-		return parseDataField(b)
-	}
-}
+	var err error
 
-// fixupLeader fixes a 23 byte leader by placing adding a single byte into an
-// implementation specific position.
-func fixupLeader(b []byte) []byte {
-	if len(b) == 23 {
-		return append(append(b[0:18], []byte(" ")...), b[18:]...)
-	}
-	return b
-}
+	bw := bufio.NewWriter(os.Stdout)
+	w := &stickyErrWriter{bw, &err}
 
-// parseRecord parses a textual marc record.
-func parseRecord(b []byte) *marc21.Record {
-	record := &marc21.Record{}
-	br := bufio.NewReader(bytes.NewReader(b))
-	for {
-		b, err := br.ReadBytes('\n')
-		if err == io.EOF {
-			break
-		}
+	// finish ends the collection and flushes the output, keeping the
+	// records converted so far.
+	finish := func() error {
+		io.WriteString(w, "</collection>\n")
 		if err != nil {
-			log.Fatal(err)
-		}
-		b = bytes.TrimSpace(b)
-		if len(b) == 0 {
-			continue
-		}
-		switch string(b[1:4]) {
-		case "LDR":
-			lb := fixupLeader(b[6:])
-			leader, err := marc21.ParseLeader(bytes.NewReader(lb))
-			if err != nil {
-				log.Fatal(err)
-			}
-			record.Leader = leader
-		default:
-			f, err := parseField(b)
-			if err != nil {
-				continue
-			}
-			record.AddField(f)
+			return err
 		}
+		return bw.Flush()
 	}
-	return record
-}
-
-func main() {
-	br := bufio.NewReader(os.Stdin)
-
-	var buf bytes.Buffer
-	var once sync.Once
-	var err error
 
-	w := &stickyErrWriter{os.Stdout, &err}
+	r := marc21.NewTextReader(os.Stdin)
+	if *controlTags != "" {
+		r.IsControlField = marc21.ControlTags(strings.Split(*controlTags, ",")...)
+	}
 
-	for {
-		b, err := br.ReadBytes('\n')
-		if err == io.EOF {
-			break
-		}
-		if fieldPattern.Match(b) {
-			// This is true, when we have a complete record buffered.
-			if len(buf.Bytes()) > 0 && bytes.HasPrefix(b, []byte("=LDR")) {
-				record := parseRecord(buf.Bytes())
-				once.Do(func() {
-					io.WriteString(w, declaration)
-					io.WriteString(w, `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
-				})
-				record.WriteTo(w)
-				buf.Reset()
-			}
-		}
-		if _, err := buf.Write(b); err != nil {
-			log.Fatal(err)
-		}
+	io.WriteString(w, declaration)
+	io.WriteString(w, `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
+	for r.Scan() {
+		r.Record().WriteTo(w)
+	}
+	if r.Err() != nil {
+		finish()
+		log.Fatal(r.Err())
 	}
-	io.WriteString(w, "</collection>\n")
-	if *w.err != nil {
+	if err := finish(); err != nil {
 		log.Fatal(err)
 	}
 }
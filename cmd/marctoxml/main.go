@@ -19,12 +19,16 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"flag"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"runtime"
 	"runtime/pprof"
+	"strings"
 	"sync"
 
 	"github.com/miku/marc21"
@@ -52,8 +56,24 @@ func (sew stickyErrWriter) Write(p []byte) (n int, err error) {
 
 func main() {
 	cpuprofile := flag.String("cpuprofile", "", "write cpu profile to this file")
+	marc8 := flag.Bool("marc8", true, "convert MARC-8 records to UTF-8")
+	codetables := flag.String("codetables", "", "load MARC-8 code tables from this LC codetables.xml file")
+	controlTags := flag.String("control-tags", "", "comma separated list of tags to read as control fields, e.g. 001,005,008,SYS")
+	iso2709 := flag.Bool("iso2709", false, "accept other ISO 2709 formats, like UNIMARC, honouring the leader entry map")
+	workers := flag.Int("w", runtime.NumCPU(), "number of decoding workers")
 	flag.Parse()
 
+	if *codetables != "" {
+		f, err := os.Open(*codetables)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := marc21.LoadMARC8Tables(f); err != nil {
+			log.Fatal(err)
+		}
+		f.Close()
+	}
+
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
 		if err != nil {
@@ -87,19 +107,32 @@ func main() {
 	w := &stickyErrWriter{writer, &err}
 	var once sync.Once
 
-	for {
-		record, err := marc21.ReadRecord(reader)
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			log.Fatal(err)
+	p := marc21.NewPipeline(reader)
+	p.Workers = *workers
+	p.ConvertMARC8 = *marc8
+	p.OnMARC8Error = func(record *marc21.Record, err error) {
+		log.Printf("%s: %v", record.Identifier(), err)
+	}
+	p.ISO2709 = *iso2709
+	if *controlTags != "" {
+		p.IsControlField = marc21.ControlTags(strings.Split(*controlTags, ",")...)
+	}
+	toXML := func(record *marc21.Record) (interface{}, error) {
+		var buf bytes.Buffer
+		if _, err := record.WriteTo(&buf); err != nil {
+			return nil, err
 		}
+		return buf.Bytes(), nil
+	}
+	if err := p.Map(context.Background(), toXML, func(v interface{}) error {
 		once.Do(func() {
 			io.WriteString(w, declaration)
 			io.WriteString(w, `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
 		})
-		record.WriteTo(w)
+		_, err := w.Write(v.([]byte))
+		return err
+	}); err != nil {
+		log.Fatal(err)
 	}
 	io.WriteString(w, "</collection>\n")
 	if *w.err != nil {
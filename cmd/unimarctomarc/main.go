@@ -0,0 +1,114 @@
+// unimarctomarc converts UNIMARC bibliographic records to MARC21.
+//
+// Records are read in binary format from a file or standard input and
+// written as binary MARC21 (default), MARCXML, MARC Breaker text or
+// MARC-in-JSON. With -v, the fields and subfields that could not be
+// converted are summarized on standard error.
+//
+//	$ unimarctomarc -f xml unimarc.mrc > marc21.xml
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/miku/marc21"
+	"github.com/miku/marc21/unimarc"
+)
+
+// recordWriter is implemented by the record writers of the marc21 package.
+type recordWriter interface {
+	Write(record *marc21.Record) error
+}
+
+func main() {
+	format := flag.String("f", "marc", "output format: marc, xml, mrk or json")
+	verbose := flag.Bool("v", false, "report unmapped fields and subfields on stderr")
+	flag.Parse()
+
+	var reader io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	bw := bufio.NewWriter(os.Stdout)
+
+	var (
+		w  recordWriter
+		xw *marc21.XMLWriter
+	)
+	// finish ends the output, keeping the records written so far.
+	finish := func() error {
+		if xw != nil {
+			if err := xw.Close(); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	}
+	fatal := func(err error) {
+		finish()
+		log.Fatal(err)
+	}
+	switch *format {
+	case "marc":
+		w = marc21.NewWriter(bw)
+	case "xml":
+		xw = marc21.NewXMLWriter(bw)
+		w = xw
+	case "mrk":
+		w = marc21.NewTextWriter(bw)
+	case "json":
+		w = marc21.NewJSONWriter(bw)
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	unmapped := make(map[string]int)
+	r := marc21.NewReader(reader)
+	r.ISO2709 = true
+	for r.Scan() {
+		record, report := unimarc.Convert(r.Record())
+		for _, f := range report.Unmapped {
+			unmapped[f.GetTag()]++
+		}
+		for _, s := range report.UnmappedSubfields {
+			unmapped[s]++
+		}
+		if *verbose {
+			for _, note := range report.Notes {
+				log.Printf("%s: %s", r.Record().Identifier(), note)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			fatal(err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		fatal(err)
+	}
+	if err := finish(); err != nil {
+		log.Fatal(err)
+	}
+	if *verbose {
+		keys := make([]string, 0, len(unmapped))
+		for k := range unmapped {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(os.Stderr, "%s\t%d\n", k, unmapped[k])
+		}
+		log.Printf("%d records converted", r.Count())
+	}
+}
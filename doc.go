@@ -26,4 +26,43 @@
 //     marcfile, err := os.Open("somedata.mrc")
 //     record, err := marc21.ReadRecord(marcfile)
 //     err = record.WriteTo(os.Stdout)
+//
+// Streams of records are best read with a Reader.
+//
+//     r := marc21.NewReader(marcfile)
+//     for r.Scan() {
+//         err = r.Record().WriteTo(os.Stdout)
+//     }
+//     err = r.Err()
+//
+// Records can be written back in binary format with a Writer.
+//
+//     w := marc21.NewWriter(os.Stdout)
+//     err = w.Write(record)
+//
+// MARCXML documents are read with an XMLReader, which has the same methods
+// as a Reader, and written as a collection with an XMLWriter, which must be
+// closed after the last record.
+//
+//     r := marc21.NewXMLReader(xmlfile)
+//
+// Records implement json.Marshaler and json.Unmarshaler for the MARC-in-JSON
+// format; JSONReader and JSONWriter handle line-delimited streams.
+//
+// Records in the MARC Breaker text format (.mrk) are read with a TextReader
+// and written with a TextWriter. AlephReader and AlephWriter handle the
+// Aleph sequential format.
+//
+// Reader.NextLazy returns records, whose fields are only decoded when they
+// are accessed, and Reader.Tags skips all but the given fields.
+//
+// Large binary files are decoded on all cores with a Pipeline, which
+// delivers the records in their original order.
+//
+// Input from untrusted sources should be read with Limits, like
+// DefaultLimits, which bound the memory used per record and the total
+// number of bytes read. Decoding errors are returned as a *ParseError.
+//
+// Records read with KeepRaw retain their original bytes and are written
+// byte for byte as read, unless they have been changed, see Record.Dirty.
 package marc21
@@ -0,0 +1,160 @@
+package marc21
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrorKind classifies the errors of decoding binary records.
+type ErrorKind int
+
+const (
+	// BadLeader is an invalid leader, like a non-numeric record length.
+	BadLeader ErrorKind = iota + 1
+	// BadDirectory is an invalid directory entry, a directory without
+	// field terminator or entries pointing outside of the record.
+	BadDirectory
+	// BadField is a field without field terminator or indicators.
+	BadField
+	// MissingTerminator is a record, that does not end with a record
+	// terminator.
+	MissingTerminator
+	// Truncated is a record cut short by the end of the input.
+	Truncated
+	// LimitExceeded is a record or input exceeding one of the Limits. The
+	// Err of the ParseError tells which one.
+	LimitExceeded
+)
+
+// String returns the name of the kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case BadLeader:
+		return "bad leader"
+	case BadDirectory:
+		return "bad directory"
+	case BadField:
+		return "bad field"
+	case MissingTerminator:
+		return "missing record terminator"
+	case Truncated:
+		return "truncated record"
+	case LimitExceeded:
+		return "limit exceeded"
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// ParseError describes a binary record, that could not be decoded. It is
+// returned by ReadRecord, Reader, Pipeline and LazyRecord decoding; use
+// errors.As to access it. The position in the stream is only known to a
+// Reader or a Pipeline.
+type ParseError struct {
+	Kind ErrorKind
+	// Index is the zero based position of the record in the stream, or -1
+	// if unknown.
+	Index int
+	// Offset is the byte offset of the record in the stream, or -1 if
+	// unknown.
+	Offset int64
+	// Identifier is the control number (001) of the record, if it could
+	// be decoded before the error occurred.
+	Identifier string
+	// Tag is the tag of the field involved, if any.
+	Tag string
+	// Entry is the zero based number of the directory entry involved, or
+	// -1.
+	Entry int
+	// RawEntry is the text of that directory entry, if it could not be
+	// parsed.
+	RawEntry string
+	// Err is the underlying error.
+	Err error
+}
+
+// newParseError returns a ParseError without position and field.
+func newParseError(kind ErrorKind, err error) *ParseError {
+	return &ParseError{Kind: kind, Index: -1, Offset: -1, Entry: -1, Err: err}
+}
+
+// Error returns the error message, starting with the record and field
+// involved, as far as known.
+func (e *ParseError) Error() string {
+	var where []string
+	if e.Index >= 0 {
+		where = append(where, fmt.Sprintf("record %d", e.Index))
+	}
+	if e.Offset >= 0 {
+		where = append(where, fmt.Sprintf("at offset %d", e.Offset))
+	}
+	if e.Identifier != "" {
+		where = append(where, fmt.Sprintf("(001 %s)", e.Identifier))
+	}
+	var b strings.Builder
+	if len(where) > 0 {
+		b.WriteString(strings.Join(where, " "))
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Kind.String())
+	if e.Entry >= 0 {
+		fmt.Fprintf(&b, ", directory entry %d", e.Entry)
+		if e.RawEntry != "" {
+			fmt.Fprintf(&b, " %q", e.RawEntry)
+		}
+	}
+	if e.Tag != "" {
+		fmt.Fprintf(&b, ", field %s", e.Tag)
+	}
+	fmt.Fprintf(&b, ": %s", e.Err)
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// locate sets the position of a parse error in the stream. Other errors are
+// returned unchanged.
+func locate(err error, index int, offset int64) error {
+	if pe, ok := err.(*ParseError); ok {
+		pe.Index, pe.Offset = index, offset
+	}
+	return err
+}
+
+// readError turns an unexpected end of the input and exceeded limits into a
+// parse error.
+func readError(err error) error {
+	switch err {
+	case io.ErrUnexpectedEOF:
+		return newParseError(Truncated, err)
+	case ErrRecordTooLong, ErrInputTooLarge:
+		return limitError(err)
+	}
+	return err
+}
+
+// isLimit reports, whether err is a parse error for the given limit.
+func isLimit(err, limit error) bool {
+	pe, ok := err.(*ParseError)
+	return ok && pe.Kind == LimitExceeded && pe.Err == limit
+}
+
+// identifierOf returns the control number of a record from its directory
+// entries and data section, or an empty string. It is used for error
+// messages and does not fail on invalid entries.
+func identifierOf(dents []dirent, data string) string {
+	for _, dent := range dents {
+		if dent.tag != "001" {
+			continue
+		}
+		end := dent.startCharPos + dent.length
+		if dent.startCharPos < 0 || dent.length < 1 || end > len(data) || data[end-1] != RS {
+			return ""
+		}
+		return data[dent.startCharPos : end-1]
+	}
+	return ""
+}
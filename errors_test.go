@@ -0,0 +1,91 @@
+package marc21
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorKind(t *testing.T) {
+	valid := buildRecord("001000600000245001000006", "12345\x1e10\x1faTitle\x1e")
+	var cases = []struct {
+		about string
+		b     []byte
+		kind  ErrorKind
+		tag   string
+		entry int
+	}{
+		{"leader", append([]byte("0005xnam"), valid[8:]...), BadLeader, "", -1},
+		{"directory entry", buildRecord("0010x0600000", "12345\x1e"), BadDirectory, "", 0},
+		{"out of bounds", buildRecord("001000600000245001000006", "12345\x1e10\x1faT\x1e"), BadDirectory, "245", 1},
+		{"field terminator", buildRecord("001000600000245001000006", "12345\x1e10\x1faTitlex"), BadField, "245", 1},
+		{"record terminator", append(valid[:len(valid)-1:len(valid)-1], 'x'), MissingTerminator, "", -1},
+		{"truncated", valid[:len(valid)-3], Truncated, "", -1},
+		{"truncated leader", valid[:10], Truncated, "", -1},
+	}
+	for _, c := range cases {
+		_, err := ReadRecord(bytes.NewReader(c.b))
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: got %v, want a *ParseError", c.about, err)
+			continue
+		}
+		if pe.Kind != c.kind || pe.Tag != c.tag || pe.Entry != c.entry {
+			t.Errorf("%s: got %v %q %d, want %v %q %d", c.about, pe.Kind, pe.Tag, pe.Entry, c.kind, c.tag, c.entry)
+		}
+		if pe.Index != -1 || pe.Offset != -1 {
+			t.Errorf("%s: got index %d and offset %d, want -1", c.about, pe.Index, pe.Offset)
+		}
+	}
+	if _, err := ReadRecord(bytes.NewReader(nil)); err != io.EOF {
+		t.Errorf("empty input, got %v, want %v", err, io.EOF)
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	b := corruptStream(t)
+	r := NewReader(bytes.NewReader(b))
+	r.Next()
+	_, err := r.Next()
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Next, got %v, want a *ParseError", err)
+	}
+	if pe.Index != 1 || pe.Offset != 819 || pe.Kind != BadDirectory || pe.Entry != 0 {
+		t.Errorf("got %#v", pe)
+	}
+	if pe.RawEntry != "001XXXX00000" {
+		t.Errorf("RawEntry, got %q, want %q", pe.RawEntry, "001XXXX00000")
+	}
+	want := `record 1 at offset 819: bad directory, directory entry 0 "001XXXX00000": `
+	if !strings.HasPrefix(pe.Error(), want) {
+		t.Errorf("Error, got %q, want prefix %q", pe.Error(), want)
+	}
+
+	err = NewPipeline(bytes.NewReader(b)).Each(context.Background(), func(*Record) error { return nil })
+	if !errors.As(err, &pe) {
+		t.Fatalf("Each, got %v, want a *ParseError", err)
+	}
+	if pe.Index != 1 || pe.Offset != 819 {
+		t.Errorf("Pipeline, got index %d and offset %d, want 1 and 819", pe.Index, pe.Offset)
+	}
+}
+
+func TestParseErrorIdentifier(t *testing.T) {
+	b := buildRecord("001000600000245001000006", "12345\x1e10\x1faTitlex")
+	_, err := NewReader(bytes.NewReader(b)).Next()
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("Next, got %v, want a *ParseError", err)
+	}
+	if pe.Identifier != "12345" {
+		t.Errorf("Identifier, got %q, want %q", pe.Identifier, "12345")
+	}
+	want := "record 0 at offset 0 (001 12345): bad field, directory entry 1, field 245: "
+	if !strings.HasPrefix(pe.Error(), want) {
+		t.Errorf("Error, got %q, want prefix %q", pe.Error(), want)
+	}
+}
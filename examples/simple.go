@@ -10,17 +10,14 @@ import (
 
 func main() {
 	io.WriteString(os.Stdout, "<collection>")
-	for {
-		r, err := marc21.ReadRecord(os.Stdin)
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			log.Fatal(err)
-		}
-		if _, err := r.WriteTo(os.Stdout); err != nil {
+	r := marc21.NewReader(os.Stdin)
+	for r.Scan() {
+		if _, err := r.Record().WriteTo(os.Stdout); err != nil {
 			log.Fatal(err)
 		}
 	}
+	if err := r.Err(); err != nil {
+		log.Fatal(err)
+	}
 	io.WriteString(os.Stdout, "</collection>")
 }
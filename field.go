@@ -1,10 +1,9 @@
 package marc21
 
 import (
-	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"io"
 	"strings"
 )
 
@@ -15,11 +14,66 @@ type Field interface {
 	GetTag() string
 }
 
+// IsControlTag is the default rule deciding, whether a tag denotes a control
+// field: the MARC21 control fields 001 to 009 and all other tags starting
+// with "00", like 00A.
+func IsControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// ControlTags returns a predicate, that is true exactly for the given tags.
+// It can be used as an IsControlField option of readers and writers, e.g.
+// ControlTags("001", "005", "008", "FMT", "SYS").
+func ControlTags(tags ...string) func(tag string) bool {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return func(tag string) bool {
+		return set[tag]
+	}
+}
+
+// oneOf returns a predicate, that is true for the given tags. Unlike
+// ControlTags, it does not build a set, as the list of tags is usually
+// short.
+func oneOf(tags []string) func(tag string) bool {
+	return func(tag string) bool {
+		for _, t := range tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// checkFieldKind returns an error, if the type of a field does not match the
+// predicate deciding about control fields. A nil predicate accepts all
+// fields.
+func checkFieldKind(f Field, isControl func(tag string) bool) error {
+	if isControl == nil {
+		return nil
+	}
+	_, ok := f.(*ControlField)
+	switch want := isControl(f.GetTag()); {
+	case ok && !want:
+		return fmt.Errorf("field %s is a control field, expected a data field", f.GetTag())
+	case !ok && want:
+		return fmt.Errorf("field %s is a data field, expected a control field", f.GetTag())
+	}
+	return nil
+}
+
 // ControlField represents a control field, which contains only a tag and data.
 type ControlField struct {
 	XMLName xml.Name `xml:"controlfield"`
 	Tag     string   `xml:"tag,attr"`
 	Data    string   `xml:",chardata"`
+
+	// raw is the field as read with KeepRaw, see Raw and MarkDirty.
+	raw   string
+	dirty bool
 }
 
 // String returns the ControlField as a string.
@@ -32,22 +86,19 @@ func (cf *ControlField) GetTag() string {
 	return cf.Tag
 }
 
-func readControl(reader io.Reader, dent *dirent) (field Field, err error) {
-	data := make([]byte, dent.length)
-	n, err := io.ReadFull(reader, data)
-	if err != nil {
-		return
-	}
-	if n != dent.length {
-		err = fmt.Errorf("invalid control entry, expected %d bytes, read %d", dent.length, n)
-		return
-	}
-	if data[dent.length-1] != RS {
-		err = fmt.Errorf("invalid control entry, does not end with a field terminator")
-		return
+// Clone returns a copy of the field.
+func (cf *ControlField) Clone() *ControlField {
+	clone := *cf
+	return &clone
+}
+
+// decodeControl returns the value of a control field, given with its field
+// terminator.
+func decodeControl(data string) (string, error) {
+	if len(data) == 0 || data[len(data)-1] != RS {
+		return "", errors.New("control field does not end with a field terminator")
 	}
-	field = &ControlField{Tag: dent.tag, Data: string(data[:dent.length-1])}
-	return
+	return data[:len(data)-1], nil
 }
 
 // SubField represents a subfield, containing a single-byte code and
@@ -85,6 +136,10 @@ type DataField struct {
 	Ind1      byte   `xml:"ind1,attr"`
 	Ind2      byte   `xml:"ind2,attr"`
 	SubFields []*SubField
+
+	// raw is the field as read with KeepRaw, see Raw and MarkDirty.
+	raw   string
+	dirty bool
 }
 
 // MarshalXML customized XML serialization.
@@ -119,33 +174,116 @@ func (df *DataField) String() string {
 		strings.Join(subfields, ", "))
 }
 
-func readData(reader io.Reader, dent *dirent) (field Field, err error) {
-	data := make([]byte, dent.length)
-	n, err := io.ReadFull(reader, data)
-	if err != nil {
-		return
+// SetInd1 sets the first indicator.
+func (df *DataField) SetInd1(ind byte) {
+	df.Ind1 = ind
+}
+
+// SetInd2 sets the second indicator.
+func (df *DataField) SetInd2(ind byte) {
+	df.Ind2 = ind
+}
+
+// AddSubField appends a subfield.
+func (df *DataField) AddSubField(code byte, value string) {
+	df.SubFields = append(df.SubFields, &SubField{Code: code, Value: value})
+}
+
+// RemoveSubFields removes all subfields with the given code and returns the
+// number of subfields removed.
+func (df *DataField) RemoveSubFields(code byte) int {
+	kept := make([]*SubField, 0, len(df.SubFields))
+	for _, sf := range df.SubFields {
+		if sf.Code != code {
+			kept = append(kept, sf)
+		}
 	}
-	if n != dent.length {
-		err = fmt.Errorf("invalid data entry, expected %d bytes, read %d", dent.length, n)
-		return
+	n := len(df.SubFields) - len(kept)
+	df.SubFields = kept
+	return n
+}
+
+// SetSubField sets the value of the first subfield with the given code. If
+// there is no such subfield, one is appended.
+func (df *DataField) SetSubField(code byte, value string) {
+	for _, sf := range df.SubFields {
+		if sf.Code == code {
+			sf.Value = value
+			return
+		}
 	}
-	if data[dent.length-1] != RS {
-		err = fmt.Errorf("invalid data entry, does not end with a field terminator")
-		return
+	df.AddSubField(code, value)
+}
+
+// Clone returns a copy of the field and its subfields.
+func (df *DataField) Clone() *DataField {
+	clone := *df
+	if df.SubFields != nil {
+		clone.SubFields = make([]*SubField, len(df.SubFields))
+		for i, sf := range df.SubFields {
+			if sf != nil {
+				v := *sf
+				clone.SubFields[i] = &v
+			}
+		}
 	}
+	return &clone
+}
 
-	df := &DataField{Tag: dent.tag}
-	df.Ind1, df.Ind2 = data[0], data[1]
+// cloneField returns a copy of a control or data field. Fields of other
+// types are returned as they are.
+func cloneField(f Field) Field {
+	switch field := f.(type) {
+	case *ControlField:
+		return field.Clone()
+	case *DataField:
+		return field.Clone()
+	}
+	return f
+}
 
-	df.SubFields = make([]*SubField, 0, 1)
-	for _, sfbytes := range bytes.Split(data[2:dent.length-1], []byte{DELIM}) {
-		if len(sfbytes) == 0 {
+// decode decodes the data of a data field with the given number of
+// indicators, including the field terminator. Missing indicators are blank.
+// The subfields are appended to pool and referenced from there, the slice of
+// pointers is carved out of refs.
+func (df *DataField) decode(data string, indicators int, pool *[]SubField, refs *[]*SubField) error {
+	if len(data) == 0 || data[len(data)-1] != RS {
+		return errors.New("data field does not end with a field terminator")
+	}
+	if len(data) < indicators+1 {
+		return errors.New("data field without indicators")
+	}
+	df.Ind1, df.Ind2 = ' ', ' '
+	if indicators > 0 {
+		df.Ind1 = data[0]
+	}
+	if indicators > 1 {
+		df.Ind2 = data[1]
+	}
+
+	data = data[indicators : len(data)-1]
+	n := strings.Count(data, string(rune(DELIM))) + 1
+	if len(*refs)+n <= cap(*refs) {
+		df.SubFields = (*refs)[len(*refs) : len(*refs) : len(*refs)+n]
+		*refs = (*refs)[:len(*refs)+n]
+	} else {
+		df.SubFields = make([]*SubField, 0, n)
+	}
+	for len(data) > 0 {
+		var s string
+		if i := strings.IndexByte(data[1:], DELIM); i >= 0 {
+			s, data = data[:i+1], data[i+1:]
+		} else {
+			s, data = data, ""
+		}
+		if s[0] == DELIM {
+			s = s[1:]
+		}
+		if len(s) == 0 {
 			continue
 		}
-		sf := &SubField{Code: sfbytes[0], Value: string(sfbytes[1:])}
-		df.SubFields = append(df.SubFields, sf)
+		*pool = append(*pool, SubField{Code: s[0], Value: s[1:]})
+		df.SubFields = append(df.SubFields, &(*pool)[len(*pool)-1])
 	}
-
-	field = df
-	return
+	return nil
 }
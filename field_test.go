@@ -0,0 +1,112 @@
+package marc21
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestIsControlField(t *testing.T) {
+	var cases = []struct {
+		isControl func(string) bool
+		tag       string
+		exp       bool
+	}{
+		{IsControlTag, "001", true},
+		{IsControlTag, "00A", true},
+		{IsControlTag, "245", false},
+		{IsControlTag, "SYS", false},
+		{ControlTags("001", "SYS"), "SYS", true},
+		{ControlTags("001", "SYS"), "005", false},
+	}
+	for _, c := range cases {
+		if got := c.isControl(c.tag); got != c.exp {
+			t.Errorf("%s, got %v, want %v", c.tag, got, c.exp)
+		}
+	}
+}
+
+func TestReaderIsControlField(t *testing.T) {
+	b := buildRecord("001000400000SYS001000004", "123\x1e000123456\x1e")
+	r := NewReader(bytes.NewReader(b))
+	r.IsControlField = ControlTags("001", "SYS")
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cf, ok := record.Fields[1].(*ControlField)
+	if !ok || cf.Data != "000123456" {
+		t.Errorf("SYS, got %v, want control field 000123456", record.Fields[1])
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.IsControlField = ControlTags("001", "SYS")
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), b) {
+		t.Errorf("got %q, want %q", buf.Bytes(), b)
+	}
+	w.IsControlField = IsControlTag
+	if err := w.Write(record); err == nil {
+		t.Errorf("Write with default predicate, got nil error")
+	}
+}
+
+func TestTextReaderIsControlField(t *testing.T) {
+	const doc = "=LDR  00000nam\\\\2200000\\\\\\4500\n=001  1\n=CAT  batch 2017\n"
+	r := NewTextReader(strings.NewReader(doc))
+	if r.Scan() {
+		t.Errorf("Scan with default predicate, got true, want false")
+	}
+	r = NewTextReader(strings.NewReader(doc))
+	r.IsControlField = func(tag string) bool { return tag == "CAT" || IsControlTag(tag) }
+	if !r.Scan() {
+		t.Fatal(r.Err())
+	}
+	if cf, ok := r.Record().Fields[1].(*ControlField); !ok || cf.Data != "batch 2017" {
+		t.Errorf("CAT, got %v, want control field", r.Record().Fields[1])
+	}
+}
+
+func TestAlephReaderIsControlField(t *testing.T) {
+	const doc = "000000001 LDR   L 00000nam^^2200000^^^4500\n000000001 SYS   L 000000001\n"
+	r := NewAlephReader(strings.NewReader(doc))
+	r.IsControlField = ControlTags("SYS")
+	if !r.Scan() {
+		t.Fatal(r.Err())
+	}
+	if _, ok := r.Record().Fields[0].(*ControlField); !ok {
+		t.Errorf("SYS, got %v, want control field", r.Record().Fields[0])
+	}
+	w := NewAlephWriter(&bytes.Buffer{})
+	w.IsControlField = isAlephControlField
+	if err := w.Write(r.Record()); err == nil {
+		t.Errorf("Write with default predicate, got nil error")
+	}
+}
+
+func TestDataFieldMutation(t *testing.T) {
+	df := &DataField{Tag: "245", Ind1: ' ', Ind2: ' '}
+	df.SetInd1('1')
+	df.SetInd2('0')
+	df.AddSubField('a', "Title")
+	df.AddSubField('c', "Author")
+	df.AddSubField('a', "Other")
+	df.SetSubField('a', "Main")
+	df.SetSubField('b', "Sub")
+	if got, want := df.String(), "245 [10] [(a) Main], [(c) Author], [(a) Other], [(b) Sub]"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	clone := df.Clone()
+	if n := df.RemoveSubFields('a'); n != 2 {
+		t.Errorf("RemoveSubFields, got %d, want 2", n)
+	}
+	if got, want := df.String(), "245 [10] [(c) Author], [(b) Sub]"; got != want {
+		t.Errorf("RemoveSubFields, got %v, want %v", got, want)
+	}
+	if len(clone.SubFields) != 4 {
+		t.Errorf("Clone, got %v, want 4 subfields", clone)
+	}
+}
@@ -0,0 +1,128 @@
+//go:build ignore
+// +build ignore
+
+// gen_marc8tables generates marc8_tables_gen.go, which adds the East Asian
+// ideographs (EACC) and the extended Arabic set to the built-in MARC-8
+// character sets, from the XML version of the LC MARC-8 code tables.
+//
+// Usage:
+//
+//	go run gen_marc8tables.go [-i codetables.xml] [-o marc8_tables_gen.go]
+//
+// Without -i, the tables are downloaded from the Library of Congress.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const codeTablesURL = "https://www.loc.gov/marc/specifications/codetables.xml"
+
+// sets are the character sets to generate, by ISO code, with the names of
+// the variables and the final characters of their escape sequences.
+var sets = []struct {
+	isoCode, name, final string
+	multibyte            bool
+}{
+	{"31", "eaccEntries", "setEACC", true},
+	{"34", "extendedArabicEntries", "setExtArabic", false},
+}
+
+type codeTables struct {
+	CharacterSets []struct {
+		ISOCode string `xml:"ISOcode,attr"`
+		Codes   []struct {
+			MARC        string `xml:"marc"`
+			UCS         string `xml:"ucs"`
+			Alt         string `xml:"alt"`
+			Name        string `xml:"name"`
+			IsCombining bool   `xml:"isCombining"`
+		} `xml:"code"`
+	} `xml:"codeTable>characterSet"`
+}
+
+func main() {
+	input := flag.String("i", "", "codetables.xml, downloaded if empty")
+	output := flag.String("o", "marc8_tables_gen.go", "output file")
+	flag.Parse()
+
+	var r io.Reader
+	if *input == "" {
+		resp, err := http.Get(codeTablesURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", codeTablesURL, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(*input)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var doc codeTables
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_marc8tables.go from the LC MARC-8 code tables; DO NOT EDIT.\n\n")
+	buf.WriteString("package marc21\n\n")
+	buf.WriteString("func init() {\n")
+	for _, set := range sets {
+		fmt.Fprintf(&buf, "charsets[%s] = newCharset(%s, %v, %s)\n", set.final, set.final, set.multibyte, set.name)
+	}
+	buf.WriteString("}\n")
+	for _, set := range sets {
+		n := 0
+		fmt.Fprintf(&buf, "\nvar %s = []charEntry{\n", set.name)
+		for _, cs := range doc.CharacterSets {
+			if cs.ISOCode != set.isoCode {
+				continue
+			}
+			for _, c := range cs.Codes {
+				code, err := strconv.ParseUint(strings.TrimSpace(c.MARC), 16, 32)
+				if err != nil {
+					log.Fatalf("invalid MARC code %q", c.MARC)
+				}
+				ucs := strings.TrimSpace(c.UCS)
+				if ucs == "" {
+					ucs = strings.TrimSpace(c.Alt)
+				}
+				r, err := strconv.ParseUint(ucs, 16, 32)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(&buf, "{0x%x, 0x%04x, %v}, // %s\n", code, r, c.IsCombining, strings.Join(strings.Fields(c.Name), " "))
+				n++
+			}
+		}
+		if n == 0 {
+			log.Fatalf("character set %s not found", set.isoCode)
+		}
+		buf.WriteString("}\n")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,300 @@
+// Package index implements random access to records in binary MARC files.
+//
+// A file is scanned once with Build, which records the byte offset and the
+// length of every record under one or more keys, by default the record
+// identifier (001). The index is saved as a compact sidecar file and read
+// back with ReadIndex. A Reader then fetches records by key from any
+// io.ReaderAt, like an *os.File, without scanning the file again.
+//
+//	ix, err := index.Build(file, index.Identifier, index.SubField("035", 'a'))
+//	...
+//	records, err := index.NewReader(file, ix).Get("ocm12345")
+//
+// All keys share a single namespace.
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/miku/marc21"
+)
+
+// magic starts every index file, followed by a format version.
+const magic = "MARCIDX"
+
+// version is the current version of the index file format.
+const version = 1
+
+// maxKeyLength limits the length of keys read from an index file, so that a
+// corrupt file does not cause huge allocations.
+const maxKeyLength = 1 << 16
+
+// Entry locates a single record in a file.
+type Entry struct {
+	Key    string
+	Offset int64
+	Length int64
+}
+
+// KeyFunc returns the keys, under which a record is indexed. Only the fields
+// accessed are decoded.
+type KeyFunc func(record *marc21.LazyRecord) []string
+
+// Identifier indexes records by their identifier (001).
+func Identifier(record *marc21.LazyRecord) []string {
+	if id := record.Identifier(); id != "" {
+		return []string{id}
+	}
+	return nil
+}
+
+// SubField returns a KeyFunc, that indexes records by the values of the
+// given subfield, e.g. SubField("035", 'a').
+func SubField(tag string, code byte) KeyFunc {
+	return func(record *marc21.LazyRecord) []string {
+		var keys []string
+		for _, sf := range record.GetSubFields(tag, code) {
+			if sf.Value != "" {
+				keys = append(keys, sf.Value)
+			}
+		}
+		return keys
+	}
+}
+
+// Index maps keys to the locations of records. A key may refer to several
+// records.
+type Index struct {
+	// entries are sorted by key and offset.
+	entries []Entry
+}
+
+// Build reads all records from r and indexes them with the given key
+// functions. If no key function is given, records are indexed by their
+// identifier. Records without any key are not indexed, a key found more
+// than once in a record is indexed once.
+func Build(r io.Reader, keys ...KeyFunc) (*Index, error) {
+	return BuildReader(marc21.NewReader(r), keys...)
+}
+
+// BuildReader is like Build, but reads records from a configured
+// marc21.Reader, e.g. one in ISO 2709 mode. The reader must be positioned at
+// the start of the file, as offsets are taken from it. Records skipped by a
+// lenient reader are not indexed.
+func BuildReader(r *marc21.Reader, keys ...KeyFunc) (*Index, error) {
+	if len(keys) == 0 {
+		keys = []KeyFunc{Identifier}
+	}
+	ix := &Index{}
+	for {
+		record, err := r.NextLazy()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset := r.RecordOffset()
+		length, first := r.Offset()-offset, len(ix.entries)
+		for _, f := range keys {
+			for _, key := range f(record) {
+				if !hasKey(ix.entries[first:], key) {
+					ix.entries = append(ix.entries, Entry{Key: key, Offset: offset, Length: length})
+				}
+			}
+		}
+	}
+	ix.sort()
+	return ix, nil
+}
+
+// hasKey reports, whether one of the entries has the given key.
+func hasKey(entries []Entry, key string) bool {
+	for _, e := range entries {
+		if e.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sort orders the entries by key and offset.
+func (ix *Index) sort() {
+	sort.Slice(ix.entries, func(i, j int) bool {
+		a, b := ix.entries[i], ix.entries[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Offset < b.Offset
+	})
+}
+
+// Len returns the number of entries in the index.
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+// Lookup returns the entries for a key, in file order.
+func (ix *Index) Lookup(key string) []Entry {
+	i := sort.Search(len(ix.entries), func(i int) bool {
+		return ix.entries[i].Key >= key
+	})
+	j := i
+	for j < len(ix.entries) && ix.entries[j].Key == key {
+		j++
+	}
+	return ix.entries[i:j:j]
+}
+
+// WriteTo writes the index in its binary format: a header, the number of
+// entries and, for each entry, the key, the offset and the length, all
+// lengths and numbers as unsigned varints.
+func (ix *Index) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	write := func(b []byte) {
+		m, _ := bw.Write(b)
+		n += int64(m)
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	uvarint := func(v uint64) {
+		write(buf[:binary.PutUvarint(buf, v)])
+	}
+	write([]byte(magic))
+	write([]byte{version})
+	uvarint(uint64(len(ix.entries)))
+	for _, e := range ix.entries {
+		if len(e.Key) > maxKeyLength {
+			return n, fmt.Errorf("key %q too long", e.Key[:32])
+		}
+		uvarint(uint64(len(e.Key)))
+		write([]byte(e.Key))
+		uvarint(uint64(e.Offset))
+		uvarint(uint64(e.Length))
+	}
+	return n, bw.Flush()
+}
+
+// ReadIndex reads an index written by WriteTo.
+func ReadIndex(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("invalid index header: %s", err)
+	}
+	if !bytes.Equal(header[:len(magic)], []byte(magic)) {
+		return nil, errors.New("not an index file")
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("unsupported index version %d", header[len(magic)])
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %s", err)
+	}
+	ix := &Index{}
+	for i := uint64(0); i < n; i++ {
+		var e Entry
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index entry %d: %s", i, err)
+		}
+		if size > maxKeyLength {
+			return nil, fmt.Errorf("invalid index entry %d: key of %d bytes", i, size)
+		}
+		key := make([]byte, size)
+		if _, err := io.ReadFull(br, key); err != nil {
+			return nil, fmt.Errorf("invalid index entry %d: %s", i, err)
+		}
+		e.Key = string(key)
+		offset, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index entry %d: %s", i, err)
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index entry %d: %s", i, err)
+		}
+		e.Offset, e.Length = int64(offset), int64(length)
+		if i > 0 && e.Key < ix.entries[i-1].Key {
+			return nil, fmt.Errorf("invalid index entry %d: keys not sorted", i)
+		}
+		ix.entries = append(ix.entries, e)
+	}
+	return ix, nil
+}
+
+// Reader fetches records from a file by key.
+type Reader struct {
+	// ConvertMARC8, if true, converts MARC-8 encoded records to UTF-8.
+	ConvertMARC8 bool
+	// IsControlField, if not nil, decides which tags are decoded as control
+	// fields, see marc21.Reader.
+	IsControlField func(tag string) bool
+	// ISO2709, if true, accepts records of other ISO 2709 formats, see
+	// marc21.Reader.
+	ISO2709 bool
+
+	ra io.ReaderAt
+	ix *Index
+}
+
+// NewReader returns a new Reader, that fetches records from ra using the
+// index ix.
+func NewReader(ra io.ReaderAt, ix *Index) *Reader {
+	return &Reader{ra: ra, ix: ix}
+}
+
+// Raw returns the bytes of the record at the given entry.
+func (r *Reader) Raw(e Entry) ([]byte, error) {
+	b := make([]byte, e.Length)
+	if _, err := r.ra.ReadAt(b, e.Offset); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("record at offset %d: %s", e.Offset, err)
+	}
+	return b, nil
+}
+
+// Read decodes the record at the given entry.
+func (r *Reader) Read(e Entry) (*marc21.Record, error) {
+	b, err := r.Raw(e)
+	if err != nil {
+		return nil, err
+	}
+	mr := marc21.NewReader(bytes.NewReader(b))
+	mr.ConvertMARC8 = r.ConvertMARC8
+	mr.IsControlField = r.IsControlField
+	mr.ISO2709 = r.ISO2709
+	record, err := mr.Next()
+	if pe, ok := err.(*marc21.ParseError); ok {
+		pe.Index, pe.Offset = -1, e.Offset
+		return nil, pe
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record at offset %d: %s", e.Offset, err)
+	}
+	return record, nil
+}
+
+// Get returns all records indexed under key, in file order. It returns no
+// records and no error, if the key is not in the index.
+func (r *Reader) Get(key string) ([]*marc21.Record, error) {
+	entries := r.ix.Lookup(key)
+	records := make([]*marc21.Record, 0, len(entries))
+	for _, e := range entries {
+		record, err := r.Read(e)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,110 @@
+package index
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/miku/marc21"
+)
+
+func openTestMARC(t *testing.T) *os.File {
+	file, err := os.Open("../fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestIndex(t *testing.T) {
+	file := openTestMARC(t)
+	defer file.Close()
+
+	ix, err := Build(file, Identifier, SubField("035", 'a'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ix.Len() < 85 {
+		t.Errorf("Len, got %d, want at least 85", ix.Len())
+	}
+	var buf bytes.Buffer
+	if _, err := ix.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if ix, err = ReadIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	r := NewReader(file, ix)
+	mr := marc21.NewReader(openTestMARC(t))
+	for mr.Scan() {
+		want := mr.Record()
+		records, err := r.Get(want.Identifier())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The identifiers in the test file are not unique.
+		found := false
+		for _, record := range records {
+			found = found || record.String() == want.String()
+		}
+		if !found {
+			t.Errorf("Get(%q), got %d records, none matching", want.Identifier(), len(records))
+		}
+		for _, sf := range want.GetSubFields("035", 'a') {
+			found := false
+			for _, e := range ix.Lookup(sf.Value) {
+				record, err := r.Read(e)
+				if err != nil {
+					t.Fatal(err)
+				}
+				found = found || record.Identifier() == want.Identifier()
+			}
+			if !found {
+				t.Errorf("Lookup(%q), record %q not found", sf.Value, want.Identifier())
+			}
+		}
+	}
+	if mr.Err() != nil {
+		t.Fatal(mr.Err())
+	}
+	if records, err := r.Get("no such key"); err != nil || len(records) != 0 {
+		t.Errorf("Get of missing key, got %d records and %v, want none", len(records), err)
+	}
+}
+
+func TestBuildReaderLenient(t *testing.T) {
+	b, err := ioutil.ReadFile("../fixtures/sandburg.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const garbage = "00042nam  not a record\x1d"
+	b = append([]byte(garbage), b...)
+	mr := marc21.NewReader(bytes.NewReader(b))
+	mr.Lenient = true
+	ix, err := BuildReader(mr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const id = "   92005291 "
+	entries := ix.Lookup(id)
+	if len(entries) != 1 || entries[0].Offset != int64(len(garbage)) || entries[0].Length != int64(len(b)-len(garbage)) {
+		t.Fatalf("Lookup, got %v, want the record after the skipped one", entries)
+	}
+	records, err := NewReader(bytes.NewReader(b), ix).Get(id)
+	if err != nil || len(records) != 1 {
+		t.Errorf("Get, got %d records and %v, want one record", len(records), err)
+	}
+}
+
+func TestReadIndexInvalid(t *testing.T) {
+	for _, s := range []string{"", "MARCIDX", "NOINDEX\x01\x00", "MARCIDX\x02\x00", "MARCIDX\x01\x01\x05ab"} {
+		if _, err := ReadIndex(bytes.NewReader([]byte(s))); err == nil {
+			t.Errorf("ReadIndex(%q), got nil, want some error", s)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package marc21
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonDataField is the MARC-in-JSON representation of a data field, without
+// the tag.
+type jsonDataField struct {
+	Ind1      string      `json:"ind1"`
+	Ind2      string      `json:"ind2"`
+	SubFields []*SubField `json:"subfields"`
+}
+
+// byteString returns a string of the single byte b. Unlike string(b), it
+// does not encode bytes from 0x80 as UTF-8, which JSON encoding turns into
+// U+FFFD, as they are not valid UTF-8 by themselves.
+func byteString(b byte) string {
+	return string([]byte{b})
+}
+
+// MarshalJSON encodes a subfield as MARC-in-JSON, e.g. {"a": "value"}.
+func (sf *SubField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{byteString(sf.Code): sf.Value})
+}
+
+// UnmarshalJSON decodes a MARC-in-JSON subfield.
+func (sf *SubField) UnmarshalJSON(b []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	if len(m) != 1 {
+		return fmt.Errorf("invalid subfield, expected a single code, got %d", len(m))
+	}
+	for code, value := range m {
+		if len(code) != 1 {
+			return fmt.Errorf("invalid subfield code %q", code)
+		}
+		sf.Code, sf.Value = code[0], value
+	}
+	return nil
+}
+
+// MarshalJSON encodes a control field as MARC-in-JSON, e.g. {"001": "123"}.
+func (cf *ControlField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{cf.Tag: cf.Data})
+}
+
+// MarshalJSON encodes a data field as MARC-in-JSON, e.g.
+// {"245": {"ind1": "1", "ind2": "0", "subfields": [{"a": "Title"}]}}.
+func (df *DataField) MarshalJSON() ([]byte, error) {
+	subfields := df.SubFields
+	if subfields == nil {
+		subfields = []*SubField{}
+	}
+	return json.Marshal(map[string]jsonDataField{df.Tag: {
+		Ind1:      byteString(df.Ind1),
+		Ind2:      byteString(df.Ind2),
+		SubFields: subfields,
+	}})
+}
+
+// UnmarshalJSON decodes a MARC-in-JSON data field.
+func (df *DataField) UnmarshalJSON(b []byte) error {
+	var m map[string]jsonDataField
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	if len(m) != 1 {
+		return fmt.Errorf("invalid field, expected a single tag, got %d", len(m))
+	}
+	for tag, v := range m {
+		df.Tag = tag
+		df.Ind1 = indicator(v.Ind1)
+		df.Ind2 = indicator(v.Ind2)
+		df.SubFields = v.SubFields
+	}
+	return nil
+}
+
+// unmarshalJSONField decodes a MARC-in-JSON field. Control fields have a
+// string value, data fields an object.
+func unmarshalJSONField(b []byte) (Field, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(m) != 1 {
+		return nil, fmt.Errorf("invalid field, expected a single tag, got %d", len(m))
+	}
+	for tag, v := range m {
+		if len(v) > 0 && v[0] == '"' {
+			cf := &ControlField{Tag: tag}
+			if err := json.Unmarshal(v, &cf.Data); err != nil {
+				return nil, err
+			}
+			return cf, nil
+		}
+	}
+	df := &DataField{}
+	if err := json.Unmarshal(b, df); err != nil {
+		return nil, err
+	}
+	return df, nil
+}
+
+// jsonRecord is the MARC-in-JSON representation of a record.
+type jsonRecord struct {
+	Leader string            `json:"leader"`
+	Fields []json.RawMessage `json:"fields"`
+}
+
+// MarshalJSON encodes a record as MARC-in-JSON. A record without leader is
+// an error, as it cannot be decoded again.
+func (record *Record) MarshalJSON() ([]byte, error) {
+	if record.Leader == nil {
+		return nil, errors.New("record without leader")
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"leader":`)
+	b, err := json.Marshal(record.Leader.String())
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+	buf.WriteString(`,"fields":[`)
+	for i, f := range record.Fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		b, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a MARC-in-JSON record.
+func (record *Record) UnmarshalJSON(b []byte) error {
+	var jr jsonRecord
+	if err := json.Unmarshal(b, &jr); err != nil {
+		return err
+	}
+	if jr.Leader == "" {
+		return errors.New("record without leader")
+	}
+	leader, err := parseLeaderString(jr.Leader)
+	if err != nil {
+		return err
+	}
+	record.Leader = leader
+	record.Fields = make([]Field, 0, len(jr.Fields))
+	for _, raw := range jr.Fields {
+		f, err := unmarshalJSONField(raw)
+		if err != nil {
+			return err
+		}
+		record.Fields = append(record.Fields, f)
+	}
+	return nil
+}
+
+// JSONWriter writes records as line-delimited MARC-in-JSON, one record per
+// line.
+type JSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONWriter returns a new JSONWriter that writes to w.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONWriter{enc: enc}
+}
+
+// Write writes a single record, followed by a newline.
+func (w *JSONWriter) Write(record *Record) error {
+	return w.enc.Encode(record)
+}
+
+// JSONReader reads records from a stream of MARC-in-JSON objects, usually
+// one per line.
+type JSONReader struct {
+	dec    *json.Decoder
+	record *Record
+	err    error
+	count  int
+}
+
+// NewJSONReader returns a new JSONReader that reads from r.
+func NewJSONReader(r io.Reader) *JSONReader {
+	return &JSONReader{dec: json.NewDecoder(bufio.NewReader(r))}
+}
+
+// Next returns the next record from the stream. It returns io.EOF, if there
+// are no more records.
+func (r *JSONReader) Next() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	record := &Record{}
+	if err := r.dec.Decode(record); err != nil {
+		r.err = err
+		return nil, err
+	}
+	r.count++
+	r.record = record
+	return record, nil
+}
+
+// Scan advances the reader to the next record, which will then be available
+// through the Record method. It returns false when the stream is exhausted or
+// an error occurred.
+func (r *JSONReader) Scan() bool {
+	_, err := r.Next()
+	return err == nil
+}
+
+// Record returns the most recent record read by a call to Scan or Next.
+func (r *JSONReader) Record() *Record {
+	return r.record
+}
+
+// Err returns the first non-EOF error that was encountered by the reader.
+func (r *JSONReader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Count returns the number of records read so far.
+func (r *JSONReader) Count() int {
+	return r.count
+}
@@ -0,0 +1,122 @@
+package marc21
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	record := &Record{Leader: defaultLeader()}
+	record.AddField(&ControlField{Tag: "001", Data: "123"})
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "Title <&>"},
+		{Code: 'c', Value: "Author"},
+	}})
+	b, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const exp = `{"leader":"00000nam a2200000   4500","fields":[{"001":"123"},{"245":{"ind1":"1","ind2":"0","subfields":[{"a":"Title \u003c\u0026\u003e"},{"c":"Author"}]}}]}`
+	if string(b) != exp {
+		t.Errorf("got %s, want %s", b, exp)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	const doc = `{
+		"leader": "01471cjm  2200349 a 4500",
+		"fields": [
+			{"001": "5674874"},
+			{"650": {"ind1": " ", "ind2": "0", "subfields": [{"a": "Rock music"}, {"y": "1961-1970."}]}},
+			{"650": {"ind1": " ", "ind2": "0", "subfields": [{"a": "Rock music"}, {"a": "Again"}]}}
+		]
+	}`
+	var record Record
+	if err := json.Unmarshal([]byte(doc), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Leader.String() != "01471cjm  2200349 a 4500" {
+		t.Errorf("Leader, got %v", record.Leader)
+	}
+	const exp = "001 5674874\n650 [ 0] [(a) Rock music], [(y) 1961-1970.]\n650 [ 0] [(a) Rock music], [(a) Again]"
+	if record.String() != exp {
+		t.Errorf("got %v, want %v", record.String(), exp)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	var cases = []string{
+		`{"fields": []}`,
+		`{"leader": "01471cjm  2200349 a 4500", "fields": [{"001": "1", "002": "2"}]}`,
+		`{"leader": "01471cjm  2200349 a 4500", "fields": [{"245": {"subfields": [{"ab": "x"}]}}]}`,
+	}
+	for _, c := range cases {
+		var record Record
+		if err := json.Unmarshal([]byte(c), &record); err == nil {
+			t.Errorf("%s: got nil, want some error", c)
+		}
+	}
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	var records []*Record
+	var buf bytes.Buffer
+	w := NewJSONWriter(&buf)
+	r := NewReader(data)
+	for r.Scan() {
+		records = append(records, r.Record())
+		if err := w.Write(r.Record()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(records) {
+		t.Errorf("lines, got %d, want %d", n, len(records))
+	}
+
+	jr := NewJSONReader(&buf)
+	var i int
+	for jr.Scan() {
+		record := jr.Record()
+		if record.String() != records[i].String() {
+			t.Errorf("record %d, got %v, want %v", i, record, records[i])
+		}
+		if record.Leader.String() != records[i].Leader.String() {
+			t.Errorf("record %d, got leader %v, want %v", i, record.Leader, records[i].Leader)
+		}
+		i++
+	}
+	if err := jr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if jr.Count() != len(records) {
+		t.Errorf("Count, got %v, want %v", jr.Count(), len(records))
+	}
+}
+
+func TestMarshalJSONWithoutLeader(t *testing.T) {
+	record := &Record{}
+	record.AddField(&ControlField{Tag: "001", Data: "123"})
+	if b, err := json.Marshal(record); err == nil {
+		t.Errorf("Marshal, got %s, want some error", b)
+	}
+}
+
+func TestMarshalJSONNonASCII(t *testing.T) {
+	df := &DataField{Tag: "245", Ind1: 0xe9, Ind2: '0', SubFields: []*SubField{{Code: 0xe9, Value: "é"}}}
+	b, err := json.Marshal(df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"245":{"ind1":"�","ind2":"0","subfields":[{"�":"é"}]}}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
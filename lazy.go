@@ -0,0 +1,173 @@
+package marc21
+
+import (
+	"errors"
+	"strings"
+)
+
+// LazyRecord is a record, whose fields are decoded on demand. The leader and
+// the directory are parsed and checked up front, a field is decoded the
+// first time it is accessed. This is faster than decoding a complete Record,
+// if only a few fields of each record are used. LazyRecords are read with
+// Reader.NextLazy.
+type LazyRecord struct {
+	Leader *Leader
+
+	data       string
+	dents      []dirent
+	fields     []Field
+	indicators int
+	opts       decodeOptions
+	raw        string
+	marc8      bool
+	err        error
+}
+
+// newLazyRecord parses the directory of a record and checks, that all
+// fields to be decoded are terminated and have their indicators, so that
+// decoding them later cannot fail.
+func newLazyRecord(leader *Leader, body string, opts decodeOptions, convertMARC8 bool) (*LazyRecord, error) {
+	dents, data, indicators, err := parseDirectory(leader, body, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, dent := range dents {
+		b := data[dent.startCharPos : dent.startCharPos+dent.length]
+		if max := opts.limits.MaxSubFields; max > 0 && !opts.isControl(dent.tag) &&
+			strings.Count(b, string(rune(DELIM))) > max {
+			pe := limitError(ErrTooManySubFields)
+			pe.Tag, pe.Entry, pe.Identifier = dent.tag, dent.entry, identifierOf(dents, data)
+			return nil, pe
+		}
+		var err error
+		if b[len(b)-1] != RS {
+			err = errors.New("field does not end with a field terminator")
+		} else if !opts.isControl(dent.tag) && len(b) < indicators+1 {
+			err = errors.New("data field without indicators")
+		}
+		if err != nil {
+			pe := newParseError(BadField, err)
+			pe.Tag, pe.Entry, pe.Identifier = dent.tag, dent.entry, identifierOf(dents, data)
+			return nil, pe
+		}
+	}
+	lr := &LazyRecord{
+		Leader:     leader,
+		data:       data,
+		dents:      dents,
+		fields:     make([]Field, len(dents)),
+		indicators: indicators,
+		opts:       opts,
+	}
+	if opts.keepRaw && opts.keep == nil {
+		lr.raw = leader.raw + body
+	}
+	if convertMARC8 && leader.CharacterEncoding != 'a' {
+		lr.marc8 = true
+		leader.CharacterEncoding = 'a'
+	}
+	return lr, nil
+}
+
+// field returns the field of the i-th directory entry, decoding it first,
+// if necessary.
+func (lr *LazyRecord) field(i int) Field {
+	if lr.fields[i] != nil {
+		return lr.fields[i]
+	}
+	dent := lr.dents[i]
+	b := lr.data[dent.startCharPos : dent.startCharPos+dent.length]
+	var f Field
+	if lr.opts.isControl(dent.tag) {
+		f = &ControlField{Tag: dent.tag, Data: b[:len(b)-1]}
+	} else {
+		df := &DataField{Tag: dent.tag}
+		pool := make([]SubField, 0, strings.Count(b, string(rune(DELIM))))
+		// Cannot fail, the field has been checked by newLazyRecord.
+		df.decode(b, lr.indicators, &pool, new([]*SubField))
+		f = df
+	}
+	if lr.opts.keepRaw {
+		setFieldRaw(f, b)
+	}
+	single := &Record{Fields: []Field{f}}
+	if lr.opts.copyValues {
+		single.copyValues()
+	}
+	if lr.marc8 {
+		if err := single.DecodeMARC8(); err != nil && lr.err == nil {
+			lr.err = err
+		}
+	}
+	lr.fields[i] = f
+	return f
+}
+
+// Tags returns the tags of all fields in directory order, without decoding
+// any field.
+func (lr *LazyRecord) Tags() []string {
+	tags := make([]string, len(lr.dents))
+	for i, dent := range lr.dents {
+		tags[i] = dent.tag
+	}
+	return tags
+}
+
+// GetFields returns a slice of fields that match the given tag. Only these
+// fields are decoded.
+func (lr *LazyRecord) GetFields(tag string) (fields []Field) {
+	fields = make([]Field, 0, 4)
+	for i, dent := range lr.dents {
+		if dent.tag == tag {
+			fields = append(fields, lr.field(i))
+		}
+	}
+	return
+}
+
+// GetSubFields returns a slice of subfields that match the given tag and
+// code.
+func (lr *LazyRecord) GetSubFields(tag string, code byte) (subfields []*SubField) {
+	subfields = make([]*SubField, 0, 4)
+	for _, field := range lr.GetFields(tag) {
+		if df, ok := field.(*DataField); ok {
+			for _, subfield := range df.SubFields {
+				if subfield.Code == code {
+					subfields = append(subfields, subfield)
+				}
+			}
+		}
+	}
+	return
+}
+
+// Identifier returns the record identifier or an empty string.
+func (lr *LazyRecord) Identifier() string {
+	for _, f := range lr.GetFields("001") {
+		if v, ok := f.(*ControlField); ok {
+			return v.Data
+		}
+	}
+	return ""
+}
+
+// Record decodes all fields and returns them as a Record. The record shares
+// its leader and fields with the LazyRecord. It also returns the first
+// error, if any, of converting fields from MARC-8, see Err.
+func (lr *LazyRecord) Record() (*Record, error) {
+	record := &Record{Leader: lr.Leader, Fields: make([]Field, len(lr.dents))}
+	for i := range lr.dents {
+		record.Fields[i] = lr.field(i)
+	}
+	if lr.raw != "" {
+		record.raw, record.fields = lr.raw, append([]Field(nil), record.Fields...)
+	}
+	return record, lr.err
+}
+
+// Err returns the first error of converting a decoded field from MARC-8 to
+// UTF-8. Fields are converted as they are decoded, if the record has been
+// read with ConvertMARC8 set.
+func (lr *LazyRecord) Err() error {
+	return lr.err
+}
@@ -0,0 +1,114 @@
+package marc21
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestLazyRecord(t *testing.T) {
+	var records []*Record
+	r := NewReader(openTestMARC(t))
+	for r.Scan() {
+		records = append(records, r.Record())
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	lazy := NewReader(openTestMARC(t))
+	for i := 0; ; i++ {
+		lr, err := lazy.NextLazy()
+		if err == io.EOF {
+			if i != len(records) {
+				t.Errorf("records, got %d, want %d", i, len(records))
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := records[i]
+		if lr.Identifier() != want.Identifier() {
+			t.Errorf("record %d, Identifier, got %q, want %q", i, lr.Identifier(), want.Identifier())
+		}
+		got, want245 := lr.GetSubFields("245", 'a'), want.GetSubFields("245", 'a')
+		if len(got) != len(want245) || (len(got) > 0 && got[0].Value != want245[0].Value) {
+			t.Errorf("record %d, 245 $a, got %v, want %v", i, got, want245)
+		}
+		for j, f := range lr.fields {
+			if f != nil && f.GetTag() != "001" && f.GetTag() != "245" {
+				t.Errorf("record %d, field %d (%s) decoded, but not accessed", i, j, f.GetTag())
+			}
+		}
+		record, err := lr.Record()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.String() != want.String() {
+			t.Errorf("record %d, got %q, want %q", i, record, want)
+		}
+	}
+}
+
+func TestLazyRecordTags(t *testing.T) {
+	lr, err := NewReader(openTestMARC(t)).NextLazy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := lr.Tags()
+	if len(tags) == 0 || tags[0] != "001" {
+		t.Errorf("Tags, got %v, want tags starting with 001", tags)
+	}
+	for i, f := range lr.fields {
+		if f != nil {
+			t.Errorf("field %d decoded by Tags", i)
+		}
+	}
+}
+
+func TestReaderTags(t *testing.T) {
+	r := NewReader(openTestMARC(t))
+	r.Tags = []string{"001", "245"}
+	for r.Scan() {
+		for _, f := range r.Record().Fields {
+			if tag := f.GetTag(); tag != "001" && tag != "245" {
+				t.Fatalf("record %d, got field %s, want only 001 and 245", r.Count(), tag)
+			}
+		}
+		if r.Record().Identifier() == "" {
+			t.Errorf("record %d, missing identifier", r.Count())
+		}
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	if r.Count() != 85 {
+		t.Errorf("Count, got %v, want %v", r.Count(), 85)
+	}
+}
+
+func BenchmarkReaderLazy(b *testing.B) {
+	data, err := ioutil.ReadFile("fixtures/test.mrc")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+		for {
+			lr, err := r.NextLazy()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+			lr.Identifier()
+			lr.GetFields("035")
+			lr.GetFields("856")
+		}
+	}
+}
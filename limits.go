@@ -0,0 +1,68 @@
+package marc21
+
+import (
+	"bufio"
+	"errors"
+)
+
+// Errors for exceeded limits, see Limits. They are returned as the Err of a
+// *ParseError of kind LimitExceeded.
+var (
+	// ErrRecordTooLong is a record longer than Limits.MaxRecordLength.
+	ErrRecordTooLong = errors.New("record exceeds the maximum length")
+	// ErrTooManyEntries is a directory with more than
+	// Limits.MaxDirectoryEntries entries.
+	ErrTooManyEntries = errors.New("directory exceeds the maximum number of entries")
+	// ErrTooManySubFields is a data field with more than
+	// Limits.MaxSubFields subfields.
+	ErrTooManySubFields = errors.New("field exceeds the maximum number of subfields")
+	// ErrInputTooLarge is an input longer than Limits.MaxTotalBytes.
+	ErrInputTooLarge = errors.New("input exceeds the maximum size")
+)
+
+// Limits bound the resources used for decoding binary records, which
+// matters for untrusted input. A zero value means no limit. Without limits,
+// the memory used for a record is bounded by its leader, except for records
+// with the lengths 00000 or 99999, which are read up to the next record
+// terminator, however far away it is.
+type Limits struct {
+	// MaxRecordLength is the maximum length of a record in bytes,
+	// including the leader. It is checked against the leader before any
+	// memory is allocated and while reading up to a record terminator.
+	MaxRecordLength int
+	// MaxDirectoryEntries is the maximum number of fields of a record.
+	MaxDirectoryEntries int
+	// MaxSubFields is the maximum number of subfields of a field.
+	MaxSubFields int
+	// MaxTotalBytes is the maximum number of bytes read from a stream.
+	MaxTotalBytes int64
+}
+
+// DefaultLimits are limits for untrusted input, generous enough for
+// oversize records found in practice.
+var DefaultLimits = Limits{
+	MaxRecordLength:     1 << 20,
+	MaxDirectoryEntries: 10000,
+	MaxSubFields:        1000,
+}
+
+// limitError returns the error for an exceeded limit.
+func limitError(err error) *ParseError {
+	return newParseError(LimitExceeded, err)
+}
+
+// readTerminated appends the bytes up to and including the next record
+// terminator to b. If max is positive and b would grow beyond max bytes, it
+// stops and returns tooLong. At the end of the input, it returns io.EOF.
+func readTerminated(br *bufio.Reader, b []byte, max int, tooLong error) ([]byte, error) {
+	for {
+		chunk, err := br.ReadSlice(RT)
+		b = append(b, chunk...)
+		if max > 0 && len(b) > max {
+			return b, tooLong
+		}
+		if err != bufio.ErrBufferFull {
+			return b, err
+		}
+	}
+}
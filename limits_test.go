@@ -0,0 +1,118 @@
+package marc21
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	valid := buildRecord("001000600000245001800006", "12345\x1e10\x1faTitle\x1fbSub\x1fcX\x1e")
+	oversize := append([]byte("99999"), valid[5:]...)
+	unterminated := append(oversize[:24:24], bytes.Repeat([]byte("x"), 5000)...)
+	var cases = []struct {
+		about  string
+		b      []byte
+		limits Limits
+		err    error
+	}{
+		{"record length", valid, Limits{MaxRecordLength: len(valid) - 1}, ErrRecordTooLong},
+		{"oversize record length", oversize, Limits{MaxRecordLength: len(valid) - 1}, ErrRecordTooLong},
+		{"unterminated", unterminated, Limits{MaxRecordLength: 1000}, ErrRecordTooLong},
+		{"directory entries", valid, Limits{MaxDirectoryEntries: 1}, ErrTooManyEntries},
+		{"subfields", valid, Limits{MaxSubFields: 2}, ErrTooManySubFields},
+		{"total bytes", append(valid, valid...), Limits{MaxTotalBytes: int64(len(valid)) + 10}, ErrInputTooLarge},
+		{"total bytes within record", valid, Limits{MaxTotalBytes: 30}, ErrInputTooLarge},
+	}
+	for _, c := range cases {
+		r := NewReader(bytes.NewReader(c.b))
+		r.Limits = c.limits
+		for r.Scan() {
+		}
+		var pe *ParseError
+		if !errors.As(r.Err(), &pe) || pe.Kind != LimitExceeded || !errors.Is(r.Err(), c.err) {
+			t.Errorf("%s: got %v, want %v", c.about, r.Err(), c.err)
+		}
+
+		err := (&Pipeline{Limits: c.limits, r: bytes.NewReader(c.b)}).Each(context.Background(), func(*Record) error { return nil })
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: Pipeline, got %v, want %v", c.about, err, c.err)
+		}
+	}
+
+	r := NewReader(bytes.NewReader(append(valid, valid...)))
+	r.Limits = Limits{MaxRecordLength: len(valid), MaxDirectoryEntries: 2, MaxSubFields: 3, MaxTotalBytes: 2 * int64(len(valid))}
+	for r.Scan() {
+	}
+	if r.Err() != nil || r.Count() != 2 {
+		t.Errorf("within limits, got %d records and %v, want 2 records", r.Count(), r.Err())
+	}
+}
+
+func TestLimitsLenient(t *testing.T) {
+	valid := buildRecord("001000600000", "12345\x1e")
+	large := buildRecord("001002000000", strings.Repeat("x", 19)+"\x1e")
+	var b []byte
+	b = append(b, valid...)
+	b = append(b, large...)
+	b = append(b, valid...)
+	r := NewReader(bytes.NewReader(b))
+	r.Lenient = true
+	r.Limits = Limits{MaxRecordLength: len(valid) + 5}
+	var skipped []error
+	r.OnSkip = func(s *SkippedRecord) {
+		skipped = append(skipped, s.Err)
+	}
+	for r.Scan() {
+	}
+	if r.Err() != nil || r.Count() != 2 {
+		t.Errorf("got %d records and %v, want 2 records", r.Count(), r.Err())
+	}
+	if len(skipped) != 1 || !errors.Is(skipped[0], ErrRecordTooLong) {
+		t.Errorf("skipped, got %v, want %v", skipped, ErrRecordTooLong)
+	}
+}
+
+// TestReaderArbitraryBytes decodes randomly corrupted records and checks,
+// that the reader neither panics nor reads beyond the limits.
+func TestReaderArbitraryBytes(t *testing.T) {
+	data, err := ioutil.ReadFile("fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	special := []byte{RT, RS, DELIM, '0', '9', ' '}
+	for i := 0; i < 2000; i++ {
+		b := append([]byte{}, data[:2000+rnd.Intn(2000)]...)
+		for j := rnd.Intn(20); j >= 0; j-- {
+			k := rnd.Intn(len(b))
+			if rnd.Intn(2) == 0 {
+				b[k] = special[rnd.Intn(len(special))]
+			} else {
+				b[k] = byte(rnd.Intn(256))
+			}
+		}
+		for _, lenient := range []bool{false, true} {
+			r := NewReader(bytes.NewReader(b))
+			r.Lenient = lenient
+			r.Limits = DefaultLimits
+			r.ISO2709 = i%2 == 0
+			for r.Scan() {
+				_ = r.Record().String()
+			}
+			r = NewReader(bytes.NewReader(b))
+			r.Lenient = lenient
+			for {
+				lr, err := r.NextLazy()
+				if err != nil {
+					break
+				}
+				lr.Record()
+			}
+		}
+	}
+}
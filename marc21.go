@@ -1,6 +1,7 @@
 package marc21
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -11,8 +12,16 @@ type dirent struct {
 	tag          string
 	length       int
 	startCharPos int
+	// entry is the number of the entry in the directory.
+	entry int
 }
 
+const (
+	// minRecordLength is the length of a record without fields, consisting
+	// of the leader, the directory terminator and the record terminator.
+	minRecordLength = 26
+)
+
 const (
 	// RT is the record terminator.
 	RT = 0x1D
@@ -23,6 +32,8 @@ const (
 )
 
 // ErrFieldSeparator if we encounter a field separator in a weird place.
+//
+// Deprecated: Decoding errors are returned as a *ParseError.
 var ErrFieldSeparator = errors.New("Record Separator (field terminator)")
 
 // Leader represents the record leader, containing structural data about the
@@ -35,27 +46,76 @@ type Leader struct {
 	BaseAddress                        int
 	IndicatorCount, SubfieldCodeLength int
 	LengthOfLength, LengthOfStartPos   int
+	// LengthOfImplementationDefined is the length of the implementation
+	// defined part of each directory entry (leader/22), which is zero in
+	// MARC21 and only honoured by readers in ISO 2709 mode.
+	LengthOfImplementationDefined int
+
+	// raw and orig are the bytes and values of the leader as read, if it
+	// has been read with KeepRaw.
+	raw  string
+	orig *Leader
 }
 
-// Bytes returns the leader as a slice of 24 bytes.
+// Bytes returns the leader as a slice of 24 bytes. A record length or base
+// address, that does not fit into five digits, is written as 99999, as
+// commonly done for oversize records. Of a leader read with KeepRaw, the
+// values that have not been changed and leader/23 are returned as read.
 func (leader Leader) Bytes() (buf []byte) {
 	buf = make([]byte, 24)
-	copy(buf[0:5], []byte(fmt.Sprintf("%05d", leader.Length)))
+	copy(buf[0:5], []byte(fmt.Sprintf("%05d", clamp(leader.Length))))
 	buf[5] = leader.Status
 	buf[6] = leader.Type
 	copy(buf[7:9], leader.ImplementationDefined[0:2])
 	buf[9] = leader.CharacterEncoding
 	copy(buf[10:11], fmt.Sprintf("%d", leader.IndicatorCount))
 	copy(buf[11:12], fmt.Sprintf("%d", leader.SubfieldCodeLength))
-	copy(buf[12:17], fmt.Sprintf("%05d", leader.BaseAddress))
+	copy(buf[12:17], fmt.Sprintf("%05d", clamp(leader.BaseAddress)))
 	copy(buf[17:20], leader.ImplementationDefined[2:5])
 	copy(buf[20:21], fmt.Sprintf("%d", leader.LengthOfLength))
 	copy(buf[21:22], fmt.Sprintf("%d", leader.LengthOfStartPos))
-	buf[22] = '0'
+	copy(buf[22:23], fmt.Sprintf("%d", leader.LengthOfImplementationDefined))
 	buf[23] = '0'
+	if o := leader.orig; o != nil {
+		keep := func(from, to int, unchanged bool) {
+			if unchanged {
+				copy(buf[from:to], leader.raw[from:to])
+			}
+		}
+		keep(0, 5, leader.Length == o.Length)
+		keep(10, 11, leader.IndicatorCount == o.IndicatorCount)
+		keep(11, 12, leader.SubfieldCodeLength == o.SubfieldCodeLength)
+		keep(12, 17, leader.BaseAddress == o.BaseAddress)
+		keep(20, 21, leader.LengthOfLength == o.LengthOfLength)
+		keep(21, 22, leader.LengthOfStartPos == o.LengthOfStartPos)
+		keep(22, 23, leader.LengthOfImplementationDefined == o.LengthOfImplementationDefined)
+		keep(23, 24, true)
+	}
 	return
 }
 
+// clamp limits a length or address to the five digits of the leader.
+func clamp(n int) int {
+	if n > maxRecordLength {
+		return maxRecordLength
+	}
+	return n
+}
+
+// entryMap returns the number of indicators and the lengths of the parts
+// of a directory entry following the tag, as used when writing a record.
+// Leaders without these values, like a zero Leader, get the MARC21 defaults.
+func (leader Leader) entryMap() (indicators, length, start, impl int) {
+	indicators, length, start = leader.IndicatorCount, leader.LengthOfLength, leader.LengthOfStartPos
+	if indicators == 0 && leader.SubfieldCodeLength == 0 {
+		indicators = 2
+	}
+	if length == 0 && start == 0 {
+		length, start = 4, 5
+	}
+	return indicators, length, start, leader.LengthOfImplementationDefined
+}
+
 // String returns the leader as a string.
 func (leader Leader) String() string {
 	return string(leader.Bytes())
@@ -63,19 +123,36 @@ func (leader Leader) String() string {
 
 // ParseLeader parses a leader into a Leader structure.
 func ParseLeader(r io.Reader) (leader *Leader, err error) {
-	return readLeader(r)
+	return readLeader(r, false)
 }
 
-func readLeader(reader io.Reader) (leader *Leader, err error) {
-	data := make([]byte, 24)
-	n, err := io.ReadFull(reader, data)
-	if err != nil {
-		return
+// readDigit parses a single digit of the leader.
+func readDigit(b byte, name string) (int, error) {
+	if b < '0' || b > '9' {
+		return 0, fmt.Errorf("invalid %s, expected a digit, got %q", name, b)
 	}
-	if n < 23 {
-		err = fmt.Errorf("invalid leader: expected 24 bytes, read %d", n)
+	return int(b - '0'), nil
+}
+
+// readLeader reads a leader. In MARC21 mode, the indicator count and the
+// subfield code length must be 2 and the entry map at leader/22 is ignored.
+// In ISO 2709 mode (iso2709 is true), any single digit values are accepted.
+func readLeader(reader io.Reader, iso2709 bool) (leader *Leader, err error) {
+	data := make([]byte, 24)
+	if _, err = io.ReadFull(reader, data); err != nil {
 		return
 	}
+	return parseLeader(data, iso2709)
+}
+
+// parseLeader parses the 24 bytes of a leader, see readLeader. Errors are a
+// *ParseError of kind BadLeader.
+func parseLeader(data []byte, iso2709 bool) (leader *Leader, err error) {
+	defer func() {
+		if err != nil {
+			err = newParseError(BadLeader, err)
+		}
+	}()
 	leader = &Leader{}
 	leader.Length, err = strconv.Atoi(string(data[0:5]))
 	if err != nil {
@@ -87,15 +164,27 @@ func readLeader(reader io.Reader) (leader *Leader, err error) {
 	copy(leader.ImplementationDefined[0:2], data[7:9])
 	leader.CharacterEncoding = data[9]
 
-	leader.IndicatorCount, err = strconv.Atoi(string(data[10:11]))
-	if err != nil || leader.IndicatorCount != 2 {
-		err = fmt.Errorf("erroneous indicator count, expected '2', got %v", data[10])
-		return
-	}
-	leader.SubfieldCodeLength, err = strconv.Atoi(string(data[11:12]))
-	if err != nil || leader.SubfieldCodeLength != 2 {
-		err = fmt.Errorf("erroneous subfield code length, expected '2', got %v", data[11])
-		return
+	if iso2709 {
+		if leader.IndicatorCount, err = readDigit(data[10], "indicator count"); err != nil {
+			return
+		}
+		if leader.SubfieldCodeLength, err = readDigit(data[11], "subfield code length"); err != nil {
+			return
+		}
+		if leader.LengthOfImplementationDefined, err = readDigit(data[22], "length of implementation-defined portion"); err != nil {
+			return
+		}
+	} else {
+		leader.IndicatorCount, err = strconv.Atoi(string(data[10:11]))
+		if err != nil || leader.IndicatorCount != 2 {
+			err = fmt.Errorf("erroneous indicator count, expected '2', got %v", data[10])
+			return
+		}
+		leader.SubfieldCodeLength, err = strconv.Atoi(string(data[11:12]))
+		if err != nil || leader.SubfieldCodeLength != 2 {
+			err = fmt.Errorf("erroneous subfield code length, expected '2', got %v", data[11])
+			return
+		}
 	}
 
 	leader.BaseAddress, err = strconv.Atoi(string(data[12:17]))
@@ -124,33 +213,65 @@ func readLeader(reader io.Reader) (leader *Leader, err error) {
 	// 	err = fmt.Errorf("invalid length of starting character position, expected '5', got %c", data[21])
 	// 	return
 	// }
+	if iso2709 && (leader.LengthOfLength == 0 || leader.LengthOfStartPos == 0) {
+		err = fmt.Errorf("invalid entry map %q", data[20:23])
+		return
+	}
 	return
 }
 
-// readDirEnt read an direcotory entry.
-func readDirEnt(reader io.Reader) (dent *dirent, err error) {
-	data := make([]byte, 12)
-	if _, err = reader.Read(data[0:1]); err != nil {
-		return
+// parseLeaderString parses a leader given as text, as found in MARCXML or
+// MARC-in-JSON. These leaders often contain blanks instead of the record
+// length and base address, which are treated as zero.
+func parseLeaderString(s string) (*Leader, error) {
+	b := []byte(s)
+	if len(b) != 24 {
+		return nil, fmt.Errorf("invalid leader: expected 24 bytes, got %d", len(b))
 	}
-	if data[0] == RS {
-		err = ErrFieldSeparator
-		return
+	for _, r := range [][2]int{{0, 5}, {12, 17}} {
+		if len(bytes.TrimSpace(b[r[0]:r[1]])) == 0 {
+			copy(b[r[0]:r[1]], "00000")
+		}
 	}
-	n, err := io.ReadFull(reader, data[1:])
-	if err != nil {
-		return
+	for i, v := range map[int]byte{10: '2', 11: '2', 20: '4', 21: '5'} {
+		if b[i] == ' ' {
+			b[i] = v
+		}
+	}
+	return readLeader(bytes.NewReader(b), false)
+}
+
+// atoi parses a non-negative decimal number, as found in the directory. It
+// falls back to strconv.Atoi for the error message.
+func atoi(s string) (int, error) {
+	if len(s) == 0 {
+		return strconv.Atoi(s)
+	}
+	var n int
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return strconv.Atoi(s)
+		}
+		n = n*10 + int(s[i]-'0')
 	}
-	if n != 11 {
-		err = fmt.Errorf("invalid directory entry, expected 12 bytes, got %d", n)
+	return n, nil
+}
+
+// parseDirEnt parses a single directory entry, consisting of the tag, the
+// field length and the starting position, with the given number of digits,
+// and an implementation-defined part, which is ignored.
+func parseDirEnt(data string, length, start int) (dent dirent, err error) {
+	if len(data) < 3+length+start {
+		err = fmt.Errorf("invalid directory entry, expected %d bytes, got %d", 3+length+start, len(data))
 		return
 	}
-	dent = &dirent{}
-	dent.tag = string(data[0:3])
-	if dent.length, err = strconv.Atoi(string(data[3:7])); err != nil {
+	dent.tag = data[0:3]
+	if dent.length, err = atoi(data[3 : 3+length]); err != nil {
+		err = fmt.Errorf("invalid field length in directory entry %q: %s", data, err)
 		return
 	}
-	if dent.startCharPos, err = strconv.Atoi(string(data[7:12])); err != nil {
+	if dent.startCharPos, err = atoi(data[3+length : 3+length+start]); err != nil {
+		err = fmt.Errorf("invalid starting position in directory entry %q: %s", data, err)
 		return
 	}
 	return
@@ -0,0 +1,325 @@
+package marc21
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// ESC starts an escape sequence switching character sets in MARC-8.
+	ESC = 0x1B
+)
+
+// charset is a MARC-8 graphic character set.
+type charset struct {
+	final     byte
+	multibyte bool
+	// chars maps codes, with the high bits cleared, to code points.
+	chars map[int]rune
+	// combining contains the codes of combining characters.
+	combining map[int]bool
+	// codes maps code points back to codes.
+	codes map[rune]int
+}
+
+// newCharset creates a character set from a list of characters.
+func newCharset(final byte, multibyte bool, entries []charEntry) *charset {
+	cs := &charset{
+		final:     final,
+		multibyte: multibyte,
+		chars:     make(map[int]rune, len(entries)),
+		combining: make(map[int]bool),
+		codes:     make(map[rune]int, len(entries)),
+	}
+	for _, e := range entries {
+		cs.add(e)
+	}
+	return cs
+}
+
+// add adds a character to the set.
+func (cs *charset) add(e charEntry) {
+	code := e.code & 0x7f7f7f
+	cs.chars[code] = e.r
+	if _, ok := cs.codes[e.r]; !ok {
+		cs.codes[e.r] = code
+	}
+	if e.combining {
+		cs.combining[code] = true
+	}
+}
+
+// charsets contains the known MARC-8 character sets, keyed by the final
+// character of their designating escape sequence.
+var charsets = map[byte]*charset{
+	setBasicLatin:    newCharset(setBasicLatin, false, basicLatinEntries),
+	setExtendedLatin: newCharset(setExtendedLatin, false, extendedLatinEntries),
+	setEACC:          newCharset(setEACC, true, nil),
+	setBasicHebrew:   newCharset(setBasicHebrew, false, basicHebrewEntries),
+	setBasicArabic:   newCharset(setBasicArabic, false, basicArabicEntries),
+	setExtArabic:     newCharset(setExtArabic, false, nil),
+	setBasicCyrillic: newCharset(setBasicCyrillic, false, basicCyrillicEntries),
+	setExtCyrillic:   newCharset(setExtCyrillic, false, extendedCyrillicEntries),
+	setBasicGreek:    newCharset(setBasicGreek, false, basicGreekEntries),
+	setGreekSymbols:  newCharset(setGreekSymbols, false, greekSymbolEntries),
+	setSubscripts:    newCharset(setSubscripts, false, subscriptEntries),
+	setSuperscripts:  newCharset(setSuperscripts, false, superscriptEntries),
+}
+
+// LoadMARC8Tables loads character sets from the XML version of the LC MARC-8
+// code tables (codetables.xml). Characters from the file are added to the
+// built-in sets, which is required to convert East Asian ideographs, unless
+// the tables have been generated into the package. This function must not be
+// called concurrently with any conversion.
+func LoadMARC8Tables(r io.Reader) error {
+	var doc struct {
+		CharacterSets []struct {
+			ISOCode string `xml:"ISOcode,attr"`
+			Codes   []struct {
+				MARC        string `xml:"marc"`
+				UCS         string `xml:"ucs"`
+				Alt         string `xml:"alt"`
+				IsCombining bool   `xml:"isCombining"`
+			} `xml:"code"`
+		} `xml:"codeTable>characterSet"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+	for _, set := range doc.CharacterSets {
+		final, err := strconv.ParseUint(set.ISOCode, 16, 8)
+		if err != nil {
+			return fmt.Errorf("invalid character set code %q", set.ISOCode)
+		}
+		cs, ok := charsets[byte(final)]
+		if !ok {
+			cs = newCharset(byte(final), false, nil)
+			charsets[byte(final)] = cs
+		}
+		for _, c := range set.Codes {
+			code, err := strconv.ParseUint(strings.TrimSpace(c.MARC), 16, 32)
+			if err != nil {
+				return fmt.Errorf("invalid MARC code %q", c.MARC)
+			}
+			ucs := strings.TrimSpace(c.UCS)
+			if ucs == "" {
+				ucs = strings.TrimSpace(c.Alt)
+			}
+			r, err := strconv.ParseUint(ucs, 16, 32)
+			if err != nil {
+				continue
+			}
+			if code > 0xff {
+				cs.multibyte = true
+			}
+			cs.add(charEntry{int(code), rune(r), c.IsCombining})
+		}
+	}
+	return nil
+}
+
+// marc8Decoder keeps the state of a MARC-8 conversion.
+type marc8Decoder struct {
+	g0, g1 *charset
+	buf    strings.Builder
+	// pending contains combining characters, which precede their base
+	// character in MARC-8, but follow it in Unicode.
+	pending []rune
+	err     error
+}
+
+// emit writes a character, followed by any pending combining characters.
+func (d *marc8Decoder) emit(r rune) {
+	d.buf.WriteRune(r)
+	for _, c := range d.pending {
+		d.buf.WriteRune(c)
+	}
+	d.pending = d.pending[:0]
+}
+
+// fail records the first error and writes a replacement character.
+func (d *marc8Decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+	d.emit(utf8.RuneError)
+}
+
+// escape handles an escape sequence starting at b[0], which is the byte
+// following ESC. It returns the number of bytes consumed.
+func (d *marc8Decoder) escape(b []byte) int {
+	if len(b) == 0 {
+		d.fail(fmt.Errorf("incomplete escape sequence"))
+		return 0
+	}
+	switch b[0] {
+	case setGreekSymbols, setSubscripts, setSuperscripts:
+		d.g0 = charsets[b[0]]
+		return 1
+	case 's':
+		d.g0 = charsets[setBasicLatin]
+		return 1
+	}
+	var (
+		i         = 0
+		multibyte = false
+		g1        = false
+	)
+	if b[i] == '$' {
+		multibyte = true
+		i++
+	}
+	if i < len(b) {
+		switch b[i] {
+		case '(', ',':
+			i++
+		case ')', '-':
+			g1 = true
+			i++
+		}
+	}
+	if i < len(b) && b[i] == '!' {
+		i++
+	}
+	if i >= len(b) {
+		d.fail(fmt.Errorf("incomplete escape sequence %q", b))
+		return len(b)
+	}
+	cs, ok := charsets[b[i]]
+	if !ok || (multibyte && !cs.multibyte) {
+		d.fail(fmt.Errorf("unknown character set in escape sequence %q", b[:i+1]))
+		return i + 1
+	}
+	if g1 {
+		d.g1 = cs
+	} else {
+		d.g0 = cs
+	}
+	return i + 1
+}
+
+// lookup finds the character for a code in a set.
+func (d *marc8Decoder) lookup(cs *charset, code int) {
+	r, ok := cs.chars[code]
+	switch {
+	case !ok && len(cs.chars) == 0:
+		d.fail(fmt.Errorf("no code table for MARC-8 character set %q, see LoadMARC8Tables", cs.final))
+		return
+	case !ok:
+		d.fail(fmt.Errorf("unmapped MARC-8 character 0x%x in set %q", code, cs.final))
+		return
+	}
+	if cs.combining[code] {
+		d.pending = append(d.pending, r)
+		return
+	}
+	d.emit(r)
+}
+
+// decode converts MARC-8 bytes to UTF-8.
+func (d *marc8Decoder) decode(b []byte) {
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case c == ESC:
+			i += d.escape(b[i+1:])
+		case c == ' ':
+			d.emit(' ')
+		case c < 0x20 || c == 0x7f:
+			d.emit(rune(c))
+		case c == 0x88:
+			d.emit(0x98) // NON-SORT BEGIN
+		case c == 0x89:
+			d.emit(0x9c) // NON-SORT END
+		case c == 0x8d:
+			d.emit(0x200d) // ZERO WIDTH JOINER
+		case c == 0x8e:
+			d.emit(0x200c) // ZERO WIDTH NON-JOINER
+		case c < 0x80:
+			if d.g0.multibyte {
+				if i+3 > len(b) {
+					d.fail(fmt.Errorf("truncated multibyte character"))
+					return
+				}
+				d.lookup(d.g0, int(b[i])<<16|int(b[i+1])<<8|int(b[i+2]))
+				i += 2
+			} else {
+				d.lookup(d.g0, int(c))
+			}
+		case c >= 0xa1 && c < 0xff:
+			if d.g1.multibyte {
+				if i+3 > len(b) {
+					d.fail(fmt.Errorf("truncated multibyte character"))
+					return
+				}
+				d.lookup(d.g1, int(b[i]&0x7f)<<16|int(b[i+1]&0x7f)<<8|int(b[i+2]&0x7f))
+				i += 2
+			} else {
+				d.lookup(d.g1, int(c&0x7f))
+			}
+		default:
+			d.fail(fmt.Errorf("invalid MARC-8 byte 0x%x", c))
+		}
+	}
+	for _, c := range d.pending {
+		d.buf.WriteRune(c)
+	}
+}
+
+// DecodeMARC8 converts a MARC-8 encoded value to UTF-8. Combining diacritics
+// are moved behind their base characters and escape sequences switching
+// between character sets are interpreted. Each value starts with Basic Latin
+// as G0 and Extended Latin as G1. Characters that cannot be converted are
+// replaced with U+FFFD; in this case the converted value is returned along
+// with an error describing the first problem.
+func DecodeMARC8(b []byte) (string, error) {
+	d := &marc8Decoder{g0: charsets[setBasicLatin], g1: charsets[setExtendedLatin]}
+	d.decode(b)
+	return d.buf.String(), d.err
+}
+
+// decodeMARC8String is like DecodeMARC8, but returns strings consisting of
+// ASCII characters only unchanged.
+func decodeMARC8String(s string) (string, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 || s[i] == ESC {
+			return DecodeMARC8([]byte(s))
+		}
+	}
+	return s, nil
+}
+
+// DecodeMARC8 converts all values of a MARC-8 encoded record to UTF-8 and
+// sets the character coding scheme in the leader (position 09) to 'a'.
+// Records, whose leader indicates UTF-8 already, are not changed. All values
+// are converted, even if an error occurs; the first error is returned.
+func (record *Record) DecodeMARC8() (err error) {
+	if record.Leader != nil && record.Leader.CharacterEncoding == 'a' {
+		return nil
+	}
+	convert := func(s string) string {
+		v, e := decodeMARC8String(s)
+		if e != nil && err == nil {
+			err = e
+		}
+		return v
+	}
+	for _, f := range record.Fields {
+		switch field := f.(type) {
+		case *ControlField:
+			field.Data = convert(field.Data)
+		case *DataField:
+			for _, sf := range field.SubFields {
+				sf.Value = convert(sf.Value)
+			}
+		}
+	}
+	if record.Leader != nil {
+		record.Leader.CharacterEncoding = 'a'
+	}
+	return err
+}
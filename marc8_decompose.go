@@ -0,0 +1,710 @@
+package marc21
+
+// decompositions maps precomposed characters to their canonical
+// decomposition, for all characters whose components can be represented in
+// MARC-8. The table is derived from the Unicode character database.
+var decompositions = map[rune]string{
+	0x00c0: "A\u0300",                  // LATIN CAPITAL LETTER A WITH GRAVE
+	0x00c1: "A\u0301",                  // LATIN CAPITAL LETTER A WITH ACUTE
+	0x00c2: "A\u0302",                  // LATIN CAPITAL LETTER A WITH CIRCUMFLEX
+	0x00c3: "A\u0303",                  // LATIN CAPITAL LETTER A WITH TILDE
+	0x00c4: "A\u0308",                  // LATIN CAPITAL LETTER A WITH DIAERESIS
+	0x00c5: "A\u030a",                  // LATIN CAPITAL LETTER A WITH RING ABOVE
+	0x00c7: "C\u0327",                  // LATIN CAPITAL LETTER C WITH CEDILLA
+	0x00c8: "E\u0300",                  // LATIN CAPITAL LETTER E WITH GRAVE
+	0x00c9: "E\u0301",                  // LATIN CAPITAL LETTER E WITH ACUTE
+	0x00ca: "E\u0302",                  // LATIN CAPITAL LETTER E WITH CIRCUMFLEX
+	0x00cb: "E\u0308",                  // LATIN CAPITAL LETTER E WITH DIAERESIS
+	0x00cc: "I\u0300",                  // LATIN CAPITAL LETTER I WITH GRAVE
+	0x00cd: "I\u0301",                  // LATIN CAPITAL LETTER I WITH ACUTE
+	0x00ce: "I\u0302",                  // LATIN CAPITAL LETTER I WITH CIRCUMFLEX
+	0x00cf: "I\u0308",                  // LATIN CAPITAL LETTER I WITH DIAERESIS
+	0x00d1: "N\u0303",                  // LATIN CAPITAL LETTER N WITH TILDE
+	0x00d2: "O\u0300",                  // LATIN CAPITAL LETTER O WITH GRAVE
+	0x00d3: "O\u0301",                  // LATIN CAPITAL LETTER O WITH ACUTE
+	0x00d4: "O\u0302",                  // LATIN CAPITAL LETTER O WITH CIRCUMFLEX
+	0x00d5: "O\u0303",                  // LATIN CAPITAL LETTER O WITH TILDE
+	0x00d6: "O\u0308",                  // LATIN CAPITAL LETTER O WITH DIAERESIS
+	0x00d9: "U\u0300",                  // LATIN CAPITAL LETTER U WITH GRAVE
+	0x00da: "U\u0301",                  // LATIN CAPITAL LETTER U WITH ACUTE
+	0x00db: "U\u0302",                  // LATIN CAPITAL LETTER U WITH CIRCUMFLEX
+	0x00dc: "U\u0308",                  // LATIN CAPITAL LETTER U WITH DIAERESIS
+	0x00dd: "Y\u0301",                  // LATIN CAPITAL LETTER Y WITH ACUTE
+	0x00e0: "a\u0300",                  // LATIN SMALL LETTER A WITH GRAVE
+	0x00e1: "a\u0301",                  // LATIN SMALL LETTER A WITH ACUTE
+	0x00e2: "a\u0302",                  // LATIN SMALL LETTER A WITH CIRCUMFLEX
+	0x00e3: "a\u0303",                  // LATIN SMALL LETTER A WITH TILDE
+	0x00e4: "a\u0308",                  // LATIN SMALL LETTER A WITH DIAERESIS
+	0x00e5: "a\u030a",                  // LATIN SMALL LETTER A WITH RING ABOVE
+	0x00e7: "c\u0327",                  // LATIN SMALL LETTER C WITH CEDILLA
+	0x00e8: "e\u0300",                  // LATIN SMALL LETTER E WITH GRAVE
+	0x00e9: "e\u0301",                  // LATIN SMALL LETTER E WITH ACUTE
+	0x00ea: "e\u0302",                  // LATIN SMALL LETTER E WITH CIRCUMFLEX
+	0x00eb: "e\u0308",                  // LATIN SMALL LETTER E WITH DIAERESIS
+	0x00ec: "i\u0300",                  // LATIN SMALL LETTER I WITH GRAVE
+	0x00ed: "i\u0301",                  // LATIN SMALL LETTER I WITH ACUTE
+	0x00ee: "i\u0302",                  // LATIN SMALL LETTER I WITH CIRCUMFLEX
+	0x00ef: "i\u0308",                  // LATIN SMALL LETTER I WITH DIAERESIS
+	0x00f1: "n\u0303",                  // LATIN SMALL LETTER N WITH TILDE
+	0x00f2: "o\u0300",                  // LATIN SMALL LETTER O WITH GRAVE
+	0x00f3: "o\u0301",                  // LATIN SMALL LETTER O WITH ACUTE
+	0x00f4: "o\u0302",                  // LATIN SMALL LETTER O WITH CIRCUMFLEX
+	0x00f5: "o\u0303",                  // LATIN SMALL LETTER O WITH TILDE
+	0x00f6: "o\u0308",                  // LATIN SMALL LETTER O WITH DIAERESIS
+	0x00f9: "u\u0300",                  // LATIN SMALL LETTER U WITH GRAVE
+	0x00fa: "u\u0301",                  // LATIN SMALL LETTER U WITH ACUTE
+	0x00fb: "u\u0302",                  // LATIN SMALL LETTER U WITH CIRCUMFLEX
+	0x00fc: "u\u0308",                  // LATIN SMALL LETTER U WITH DIAERESIS
+	0x00fd: "y\u0301",                  // LATIN SMALL LETTER Y WITH ACUTE
+	0x00ff: "y\u0308",                  // LATIN SMALL LETTER Y WITH DIAERESIS
+	0x0100: "A\u0304",                  // LATIN CAPITAL LETTER A WITH MACRON
+	0x0101: "a\u0304",                  // LATIN SMALL LETTER A WITH MACRON
+	0x0102: "A\u0306",                  // LATIN CAPITAL LETTER A WITH BREVE
+	0x0103: "a\u0306",                  // LATIN SMALL LETTER A WITH BREVE
+	0x0104: "A\u0328",                  // LATIN CAPITAL LETTER A WITH OGONEK
+	0x0105: "a\u0328",                  // LATIN SMALL LETTER A WITH OGONEK
+	0x0106: "C\u0301",                  // LATIN CAPITAL LETTER C WITH ACUTE
+	0x0107: "c\u0301",                  // LATIN SMALL LETTER C WITH ACUTE
+	0x0108: "C\u0302",                  // LATIN CAPITAL LETTER C WITH CIRCUMFLEX
+	0x0109: "c\u0302",                  // LATIN SMALL LETTER C WITH CIRCUMFLEX
+	0x010a: "C\u0307",                  // LATIN CAPITAL LETTER C WITH DOT ABOVE
+	0x010b: "c\u0307",                  // LATIN SMALL LETTER C WITH DOT ABOVE
+	0x010c: "C\u030c",                  // LATIN CAPITAL LETTER C WITH CARON
+	0x010d: "c\u030c",                  // LATIN SMALL LETTER C WITH CARON
+	0x010e: "D\u030c",                  // LATIN CAPITAL LETTER D WITH CARON
+	0x010f: "d\u030c",                  // LATIN SMALL LETTER D WITH CARON
+	0x0112: "E\u0304",                  // LATIN CAPITAL LETTER E WITH MACRON
+	0x0113: "e\u0304",                  // LATIN SMALL LETTER E WITH MACRON
+	0x0114: "E\u0306",                  // LATIN CAPITAL LETTER E WITH BREVE
+	0x0115: "e\u0306",                  // LATIN SMALL LETTER E WITH BREVE
+	0x0116: "E\u0307",                  // LATIN CAPITAL LETTER E WITH DOT ABOVE
+	0x0117: "e\u0307",                  // LATIN SMALL LETTER E WITH DOT ABOVE
+	0x0118: "E\u0328",                  // LATIN CAPITAL LETTER E WITH OGONEK
+	0x0119: "e\u0328",                  // LATIN SMALL LETTER E WITH OGONEK
+	0x011a: "E\u030c",                  // LATIN CAPITAL LETTER E WITH CARON
+	0x011b: "e\u030c",                  // LATIN SMALL LETTER E WITH CARON
+	0x011c: "G\u0302",                  // LATIN CAPITAL LETTER G WITH CIRCUMFLEX
+	0x011d: "g\u0302",                  // LATIN SMALL LETTER G WITH CIRCUMFLEX
+	0x011e: "G\u0306",                  // LATIN CAPITAL LETTER G WITH BREVE
+	0x011f: "g\u0306",                  // LATIN SMALL LETTER G WITH BREVE
+	0x0120: "G\u0307",                  // LATIN CAPITAL LETTER G WITH DOT ABOVE
+	0x0121: "g\u0307",                  // LATIN SMALL LETTER G WITH DOT ABOVE
+	0x0122: "G\u0327",                  // LATIN CAPITAL LETTER G WITH CEDILLA
+	0x0123: "g\u0327",                  // LATIN SMALL LETTER G WITH CEDILLA
+	0x0124: "H\u0302",                  // LATIN CAPITAL LETTER H WITH CIRCUMFLEX
+	0x0125: "h\u0302",                  // LATIN SMALL LETTER H WITH CIRCUMFLEX
+	0x0128: "I\u0303",                  // LATIN CAPITAL LETTER I WITH TILDE
+	0x0129: "i\u0303",                  // LATIN SMALL LETTER I WITH TILDE
+	0x012a: "I\u0304",                  // LATIN CAPITAL LETTER I WITH MACRON
+	0x012b: "i\u0304",                  // LATIN SMALL LETTER I WITH MACRON
+	0x012c: "I\u0306",                  // LATIN CAPITAL LETTER I WITH BREVE
+	0x012d: "i\u0306",                  // LATIN SMALL LETTER I WITH BREVE
+	0x012e: "I\u0328",                  // LATIN CAPITAL LETTER I WITH OGONEK
+	0x012f: "i\u0328",                  // LATIN SMALL LETTER I WITH OGONEK
+	0x0130: "I\u0307",                  // LATIN CAPITAL LETTER I WITH DOT ABOVE
+	0x0134: "J\u0302",                  // LATIN CAPITAL LETTER J WITH CIRCUMFLEX
+	0x0135: "j\u0302",                  // LATIN SMALL LETTER J WITH CIRCUMFLEX
+	0x0136: "K\u0327",                  // LATIN CAPITAL LETTER K WITH CEDILLA
+	0x0137: "k\u0327",                  // LATIN SMALL LETTER K WITH CEDILLA
+	0x0139: "L\u0301",                  // LATIN CAPITAL LETTER L WITH ACUTE
+	0x013a: "l\u0301",                  // LATIN SMALL LETTER L WITH ACUTE
+	0x013b: "L\u0327",                  // LATIN CAPITAL LETTER L WITH CEDILLA
+	0x013c: "l\u0327",                  // LATIN SMALL LETTER L WITH CEDILLA
+	0x013d: "L\u030c",                  // LATIN CAPITAL LETTER L WITH CARON
+	0x013e: "l\u030c",                  // LATIN SMALL LETTER L WITH CARON
+	0x0143: "N\u0301",                  // LATIN CAPITAL LETTER N WITH ACUTE
+	0x0144: "n\u0301",                  // LATIN SMALL LETTER N WITH ACUTE
+	0x0145: "N\u0327",                  // LATIN CAPITAL LETTER N WITH CEDILLA
+	0x0146: "n\u0327",                  // LATIN SMALL LETTER N WITH CEDILLA
+	0x0147: "N\u030c",                  // LATIN CAPITAL LETTER N WITH CARON
+	0x0148: "n\u030c",                  // LATIN SMALL LETTER N WITH CARON
+	0x014c: "O\u0304",                  // LATIN CAPITAL LETTER O WITH MACRON
+	0x014d: "o\u0304",                  // LATIN SMALL LETTER O WITH MACRON
+	0x014e: "O\u0306",                  // LATIN CAPITAL LETTER O WITH BREVE
+	0x014f: "o\u0306",                  // LATIN SMALL LETTER O WITH BREVE
+	0x0150: "O\u030b",                  // LATIN CAPITAL LETTER O WITH DOUBLE ACUTE
+	0x0151: "o\u030b",                  // LATIN SMALL LETTER O WITH DOUBLE ACUTE
+	0x0154: "R\u0301",                  // LATIN CAPITAL LETTER R WITH ACUTE
+	0x0155: "r\u0301",                  // LATIN SMALL LETTER R WITH ACUTE
+	0x0156: "R\u0327",                  // LATIN CAPITAL LETTER R WITH CEDILLA
+	0x0157: "r\u0327",                  // LATIN SMALL LETTER R WITH CEDILLA
+	0x0158: "R\u030c",                  // LATIN CAPITAL LETTER R WITH CARON
+	0x0159: "r\u030c",                  // LATIN SMALL LETTER R WITH CARON
+	0x015a: "S\u0301",                  // LATIN CAPITAL LETTER S WITH ACUTE
+	0x015b: "s\u0301",                  // LATIN SMALL LETTER S WITH ACUTE
+	0x015c: "S\u0302",                  // LATIN CAPITAL LETTER S WITH CIRCUMFLEX
+	0x015d: "s\u0302",                  // LATIN SMALL LETTER S WITH CIRCUMFLEX
+	0x015e: "S\u0327",                  // LATIN CAPITAL LETTER S WITH CEDILLA
+	0x015f: "s\u0327",                  // LATIN SMALL LETTER S WITH CEDILLA
+	0x0160: "S\u030c",                  // LATIN CAPITAL LETTER S WITH CARON
+	0x0161: "s\u030c",                  // LATIN SMALL LETTER S WITH CARON
+	0x0162: "T\u0327",                  // LATIN CAPITAL LETTER T WITH CEDILLA
+	0x0163: "t\u0327",                  // LATIN SMALL LETTER T WITH CEDILLA
+	0x0164: "T\u030c",                  // LATIN CAPITAL LETTER T WITH CARON
+	0x0165: "t\u030c",                  // LATIN SMALL LETTER T WITH CARON
+	0x0168: "U\u0303",                  // LATIN CAPITAL LETTER U WITH TILDE
+	0x0169: "u\u0303",                  // LATIN SMALL LETTER U WITH TILDE
+	0x016a: "U\u0304",                  // LATIN CAPITAL LETTER U WITH MACRON
+	0x016b: "u\u0304",                  // LATIN SMALL LETTER U WITH MACRON
+	0x016c: "U\u0306",                  // LATIN CAPITAL LETTER U WITH BREVE
+	0x016d: "u\u0306",                  // LATIN SMALL LETTER U WITH BREVE
+	0x016e: "U\u030a",                  // LATIN CAPITAL LETTER U WITH RING ABOVE
+	0x016f: "u\u030a",                  // LATIN SMALL LETTER U WITH RING ABOVE
+	0x0170: "U\u030b",                  // LATIN CAPITAL LETTER U WITH DOUBLE ACUTE
+	0x0171: "u\u030b",                  // LATIN SMALL LETTER U WITH DOUBLE ACUTE
+	0x0172: "U\u0328",                  // LATIN CAPITAL LETTER U WITH OGONEK
+	0x0173: "u\u0328",                  // LATIN SMALL LETTER U WITH OGONEK
+	0x0174: "W\u0302",                  // LATIN CAPITAL LETTER W WITH CIRCUMFLEX
+	0x0175: "w\u0302",                  // LATIN SMALL LETTER W WITH CIRCUMFLEX
+	0x0176: "Y\u0302",                  // LATIN CAPITAL LETTER Y WITH CIRCUMFLEX
+	0x0177: "y\u0302",                  // LATIN SMALL LETTER Y WITH CIRCUMFLEX
+	0x0178: "Y\u0308",                  // LATIN CAPITAL LETTER Y WITH DIAERESIS
+	0x0179: "Z\u0301",                  // LATIN CAPITAL LETTER Z WITH ACUTE
+	0x017a: "z\u0301",                  // LATIN SMALL LETTER Z WITH ACUTE
+	0x017b: "Z\u0307",                  // LATIN CAPITAL LETTER Z WITH DOT ABOVE
+	0x017c: "z\u0307",                  // LATIN SMALL LETTER Z WITH DOT ABOVE
+	0x017d: "Z\u030c",                  // LATIN CAPITAL LETTER Z WITH CARON
+	0x017e: "z\u030c",                  // LATIN SMALL LETTER Z WITH CARON
+	0x01cd: "A\u030c",                  // LATIN CAPITAL LETTER A WITH CARON
+	0x01ce: "a\u030c",                  // LATIN SMALL LETTER A WITH CARON
+	0x01cf: "I\u030c",                  // LATIN CAPITAL LETTER I WITH CARON
+	0x01d0: "i\u030c",                  // LATIN SMALL LETTER I WITH CARON
+	0x01d1: "O\u030c",                  // LATIN CAPITAL LETTER O WITH CARON
+	0x01d2: "o\u030c",                  // LATIN SMALL LETTER O WITH CARON
+	0x01d3: "U\u030c",                  // LATIN CAPITAL LETTER U WITH CARON
+	0x01d4: "u\u030c",                  // LATIN SMALL LETTER U WITH CARON
+	0x01d5: "U\u0308\u0304",            // LATIN CAPITAL LETTER U WITH DIAERESIS AND MACRON
+	0x01d6: "u\u0308\u0304",            // LATIN SMALL LETTER U WITH DIAERESIS AND MACRON
+	0x01d7: "U\u0308\u0301",            // LATIN CAPITAL LETTER U WITH DIAERESIS AND ACUTE
+	0x01d8: "u\u0308\u0301",            // LATIN SMALL LETTER U WITH DIAERESIS AND ACUTE
+	0x01d9: "U\u0308\u030c",            // LATIN CAPITAL LETTER U WITH DIAERESIS AND CARON
+	0x01da: "u\u0308\u030c",            // LATIN SMALL LETTER U WITH DIAERESIS AND CARON
+	0x01db: "U\u0308\u0300",            // LATIN CAPITAL LETTER U WITH DIAERESIS AND GRAVE
+	0x01dc: "u\u0308\u0300",            // LATIN SMALL LETTER U WITH DIAERESIS AND GRAVE
+	0x01de: "A\u0308\u0304",            // LATIN CAPITAL LETTER A WITH DIAERESIS AND MACRON
+	0x01df: "a\u0308\u0304",            // LATIN SMALL LETTER A WITH DIAERESIS AND MACRON
+	0x01e0: "A\u0307\u0304",            // LATIN CAPITAL LETTER A WITH DOT ABOVE AND MACRON
+	0x01e1: "a\u0307\u0304",            // LATIN SMALL LETTER A WITH DOT ABOVE AND MACRON
+	0x01e2: "\u00c6\u0304",             // LATIN CAPITAL LETTER AE WITH MACRON
+	0x01e3: "\u00e6\u0304",             // LATIN SMALL LETTER AE WITH MACRON
+	0x01e6: "G\u030c",                  // LATIN CAPITAL LETTER G WITH CARON
+	0x01e7: "g\u030c",                  // LATIN SMALL LETTER G WITH CARON
+	0x01e8: "K\u030c",                  // LATIN CAPITAL LETTER K WITH CARON
+	0x01e9: "k\u030c",                  // LATIN SMALL LETTER K WITH CARON
+	0x01ea: "O\u0328",                  // LATIN CAPITAL LETTER O WITH OGONEK
+	0x01eb: "o\u0328",                  // LATIN SMALL LETTER O WITH OGONEK
+	0x01ec: "O\u0328\u0304",            // LATIN CAPITAL LETTER O WITH OGONEK AND MACRON
+	0x01ed: "o\u0328\u0304",            // LATIN SMALL LETTER O WITH OGONEK AND MACRON
+	0x01f0: "j\u030c",                  // LATIN SMALL LETTER J WITH CARON
+	0x01f4: "G\u0301",                  // LATIN CAPITAL LETTER G WITH ACUTE
+	0x01f5: "g\u0301",                  // LATIN SMALL LETTER G WITH ACUTE
+	0x01f8: "N\u0300",                  // LATIN CAPITAL LETTER N WITH GRAVE
+	0x01f9: "n\u0300",                  // LATIN SMALL LETTER N WITH GRAVE
+	0x01fa: "A\u030a\u0301",            // LATIN CAPITAL LETTER A WITH RING ABOVE AND ACUTE
+	0x01fb: "a\u030a\u0301",            // LATIN SMALL LETTER A WITH RING ABOVE AND ACUTE
+	0x01fc: "\u00c6\u0301",             // LATIN CAPITAL LETTER AE WITH ACUTE
+	0x01fd: "\u00e6\u0301",             // LATIN SMALL LETTER AE WITH ACUTE
+	0x01fe: "\u00d8\u0301",             // LATIN CAPITAL LETTER O WITH STROKE AND ACUTE
+	0x01ff: "\u00f8\u0301",             // LATIN SMALL LETTER O WITH STROKE AND ACUTE
+	0x0218: "S\u0326",                  // LATIN CAPITAL LETTER S WITH COMMA BELOW
+	0x0219: "s\u0326",                  // LATIN SMALL LETTER S WITH COMMA BELOW
+	0x021a: "T\u0326",                  // LATIN CAPITAL LETTER T WITH COMMA BELOW
+	0x021b: "t\u0326",                  // LATIN SMALL LETTER T WITH COMMA BELOW
+	0x021e: "H\u030c",                  // LATIN CAPITAL LETTER H WITH CARON
+	0x021f: "h\u030c",                  // LATIN SMALL LETTER H WITH CARON
+	0x0226: "A\u0307",                  // LATIN CAPITAL LETTER A WITH DOT ABOVE
+	0x0227: "a\u0307",                  // LATIN SMALL LETTER A WITH DOT ABOVE
+	0x0228: "E\u0327",                  // LATIN CAPITAL LETTER E WITH CEDILLA
+	0x0229: "e\u0327",                  // LATIN SMALL LETTER E WITH CEDILLA
+	0x022a: "O\u0308\u0304",            // LATIN CAPITAL LETTER O WITH DIAERESIS AND MACRON
+	0x022b: "o\u0308\u0304",            // LATIN SMALL LETTER O WITH DIAERESIS AND MACRON
+	0x022c: "O\u0303\u0304",            // LATIN CAPITAL LETTER O WITH TILDE AND MACRON
+	0x022d: "o\u0303\u0304",            // LATIN SMALL LETTER O WITH TILDE AND MACRON
+	0x022e: "O\u0307",                  // LATIN CAPITAL LETTER O WITH DOT ABOVE
+	0x022f: "o\u0307",                  // LATIN SMALL LETTER O WITH DOT ABOVE
+	0x0230: "O\u0307\u0304",            // LATIN CAPITAL LETTER O WITH DOT ABOVE AND MACRON
+	0x0231: "o\u0307\u0304",            // LATIN SMALL LETTER O WITH DOT ABOVE AND MACRON
+	0x0232: "Y\u0304",                  // LATIN CAPITAL LETTER Y WITH MACRON
+	0x0233: "y\u0304",                  // LATIN SMALL LETTER Y WITH MACRON
+	0x0340: "\u0300",                   // COMBINING GRAVE TONE MARK
+	0x0341: "\u0301",                   // COMBINING ACUTE TONE MARK
+	0x0343: "\u0313",                   // COMBINING GREEK KORONIS
+	0x0344: "\u0308\u0301",             // COMBINING GREEK DIALYTIKA TONOS
+	0x0374: "\u02b9",                   // GREEK NUMERAL SIGN
+	0x037e: ";",                        // GREEK QUESTION MARK
+	0x0386: "\u0391\u0301",             // GREEK CAPITAL LETTER ALPHA WITH TONOS
+	0x0387: "\u00b7",                   // GREEK ANO TELEIA
+	0x0388: "\u0395\u0301",             // GREEK CAPITAL LETTER EPSILON WITH TONOS
+	0x0389: "\u0397\u0301",             // GREEK CAPITAL LETTER ETA WITH TONOS
+	0x038a: "\u0399\u0301",             // GREEK CAPITAL LETTER IOTA WITH TONOS
+	0x038c: "\u039f\u0301",             // GREEK CAPITAL LETTER OMICRON WITH TONOS
+	0x038e: "\u03a5\u0301",             // GREEK CAPITAL LETTER UPSILON WITH TONOS
+	0x038f: "\u03a9\u0301",             // GREEK CAPITAL LETTER OMEGA WITH TONOS
+	0x0390: "\u03b9\u0308\u0301",       // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND TONOS
+	0x03aa: "\u0399\u0308",             // GREEK CAPITAL LETTER IOTA WITH DIALYTIKA
+	0x03ab: "\u03a5\u0308",             // GREEK CAPITAL LETTER UPSILON WITH DIALYTIKA
+	0x03ac: "\u03b1\u0301",             // GREEK SMALL LETTER ALPHA WITH TONOS
+	0x03ad: "\u03b5\u0301",             // GREEK SMALL LETTER EPSILON WITH TONOS
+	0x03ae: "\u03b7\u0301",             // GREEK SMALL LETTER ETA WITH TONOS
+	0x03af: "\u03b9\u0301",             // GREEK SMALL LETTER IOTA WITH TONOS
+	0x03b0: "\u03c5\u0308\u0301",       // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND TONOS
+	0x03ca: "\u03b9\u0308",             // GREEK SMALL LETTER IOTA WITH DIALYTIKA
+	0x03cb: "\u03c5\u0308",             // GREEK SMALL LETTER UPSILON WITH DIALYTIKA
+	0x03cc: "\u03bf\u0301",             // GREEK SMALL LETTER OMICRON WITH TONOS
+	0x03cd: "\u03c5\u0301",             // GREEK SMALL LETTER UPSILON WITH TONOS
+	0x03ce: "\u03c9\u0301",             // GREEK SMALL LETTER OMEGA WITH TONOS
+	0x0400: "\u0415\u0300",             // CYRILLIC CAPITAL LETTER IE WITH GRAVE
+	0x0401: "\u0415\u0308",             // CYRILLIC CAPITAL LETTER IO
+	0x0403: "\u0413\u0301",             // CYRILLIC CAPITAL LETTER GJE
+	0x0407: "\u0406\u0308",             // CYRILLIC CAPITAL LETTER YI
+	0x040c: "\u041a\u0301",             // CYRILLIC CAPITAL LETTER KJE
+	0x040d: "\u0418\u0300",             // CYRILLIC CAPITAL LETTER I WITH GRAVE
+	0x040e: "\u0423\u0306",             // CYRILLIC CAPITAL LETTER SHORT U
+	0x0419: "\u0418\u0306",             // CYRILLIC CAPITAL LETTER SHORT I
+	0x0439: "\u0438\u0306",             // CYRILLIC SMALL LETTER SHORT I
+	0x0450: "\u0435\u0300",             // CYRILLIC SMALL LETTER IE WITH GRAVE
+	0x0451: "\u0435\u0308",             // CYRILLIC SMALL LETTER IO
+	0x0453: "\u0433\u0301",             // CYRILLIC SMALL LETTER GJE
+	0x0457: "\u0456\u0308",             // CYRILLIC SMALL LETTER YI
+	0x045c: "\u043a\u0301",             // CYRILLIC SMALL LETTER KJE
+	0x045d: "\u0438\u0300",             // CYRILLIC SMALL LETTER I WITH GRAVE
+	0x045e: "\u0443\u0306",             // CYRILLIC SMALL LETTER SHORT U
+	0x04c1: "\u0416\u0306",             // CYRILLIC CAPITAL LETTER ZHE WITH BREVE
+	0x04c2: "\u0436\u0306",             // CYRILLIC SMALL LETTER ZHE WITH BREVE
+	0x04d0: "\u0410\u0306",             // CYRILLIC CAPITAL LETTER A WITH BREVE
+	0x04d1: "\u0430\u0306",             // CYRILLIC SMALL LETTER A WITH BREVE
+	0x04d2: "\u0410\u0308",             // CYRILLIC CAPITAL LETTER A WITH DIAERESIS
+	0x04d3: "\u0430\u0308",             // CYRILLIC SMALL LETTER A WITH DIAERESIS
+	0x04d6: "\u0415\u0306",             // CYRILLIC CAPITAL LETTER IE WITH BREVE
+	0x04d7: "\u0435\u0306",             // CYRILLIC SMALL LETTER IE WITH BREVE
+	0x04dc: "\u0416\u0308",             // CYRILLIC CAPITAL LETTER ZHE WITH DIAERESIS
+	0x04dd: "\u0436\u0308",             // CYRILLIC SMALL LETTER ZHE WITH DIAERESIS
+	0x04de: "\u0417\u0308",             // CYRILLIC CAPITAL LETTER ZE WITH DIAERESIS
+	0x04df: "\u0437\u0308",             // CYRILLIC SMALL LETTER ZE WITH DIAERESIS
+	0x04e2: "\u0418\u0304",             // CYRILLIC CAPITAL LETTER I WITH MACRON
+	0x04e3: "\u0438\u0304",             // CYRILLIC SMALL LETTER I WITH MACRON
+	0x04e4: "\u0418\u0308",             // CYRILLIC CAPITAL LETTER I WITH DIAERESIS
+	0x04e5: "\u0438\u0308",             // CYRILLIC SMALL LETTER I WITH DIAERESIS
+	0x04e6: "\u041e\u0308",             // CYRILLIC CAPITAL LETTER O WITH DIAERESIS
+	0x04e7: "\u043e\u0308",             // CYRILLIC SMALL LETTER O WITH DIAERESIS
+	0x04ec: "\u042d\u0308",             // CYRILLIC CAPITAL LETTER E WITH DIAERESIS
+	0x04ed: "\u044d\u0308",             // CYRILLIC SMALL LETTER E WITH DIAERESIS
+	0x04ee: "\u0423\u0304",             // CYRILLIC CAPITAL LETTER U WITH MACRON
+	0x04ef: "\u0443\u0304",             // CYRILLIC SMALL LETTER U WITH MACRON
+	0x04f0: "\u0423\u0308",             // CYRILLIC CAPITAL LETTER U WITH DIAERESIS
+	0x04f1: "\u0443\u0308",             // CYRILLIC SMALL LETTER U WITH DIAERESIS
+	0x04f2: "\u0423\u030b",             // CYRILLIC CAPITAL LETTER U WITH DOUBLE ACUTE
+	0x04f3: "\u0443\u030b",             // CYRILLIC SMALL LETTER U WITH DOUBLE ACUTE
+	0x04f4: "\u0427\u0308",             // CYRILLIC CAPITAL LETTER CHE WITH DIAERESIS
+	0x04f5: "\u0447\u0308",             // CYRILLIC SMALL LETTER CHE WITH DIAERESIS
+	0x04f8: "\u042b\u0308",             // CYRILLIC CAPITAL LETTER YERU WITH DIAERESIS
+	0x04f9: "\u044b\u0308",             // CYRILLIC SMALL LETTER YERU WITH DIAERESIS
+	0x1e00: "A\u0325",                  // LATIN CAPITAL LETTER A WITH RING BELOW
+	0x1e01: "a\u0325",                  // LATIN SMALL LETTER A WITH RING BELOW
+	0x1e02: "B\u0307",                  // LATIN CAPITAL LETTER B WITH DOT ABOVE
+	0x1e03: "b\u0307",                  // LATIN SMALL LETTER B WITH DOT ABOVE
+	0x1e04: "B\u0323",                  // LATIN CAPITAL LETTER B WITH DOT BELOW
+	0x1e05: "b\u0323",                  // LATIN SMALL LETTER B WITH DOT BELOW
+	0x1e08: "C\u0327\u0301",            // LATIN CAPITAL LETTER C WITH CEDILLA AND ACUTE
+	0x1e09: "c\u0327\u0301",            // LATIN SMALL LETTER C WITH CEDILLA AND ACUTE
+	0x1e0a: "D\u0307",                  // LATIN CAPITAL LETTER D WITH DOT ABOVE
+	0x1e0b: "d\u0307",                  // LATIN SMALL LETTER D WITH DOT ABOVE
+	0x1e0c: "D\u0323",                  // LATIN CAPITAL LETTER D WITH DOT BELOW
+	0x1e0d: "d\u0323",                  // LATIN SMALL LETTER D WITH DOT BELOW
+	0x1e10: "D\u0327",                  // LATIN CAPITAL LETTER D WITH CEDILLA
+	0x1e11: "d\u0327",                  // LATIN SMALL LETTER D WITH CEDILLA
+	0x1e14: "E\u0304\u0300",            // LATIN CAPITAL LETTER E WITH MACRON AND GRAVE
+	0x1e15: "e\u0304\u0300",            // LATIN SMALL LETTER E WITH MACRON AND GRAVE
+	0x1e16: "E\u0304\u0301",            // LATIN CAPITAL LETTER E WITH MACRON AND ACUTE
+	0x1e17: "e\u0304\u0301",            // LATIN SMALL LETTER E WITH MACRON AND ACUTE
+	0x1e1c: "E\u0327\u0306",            // LATIN CAPITAL LETTER E WITH CEDILLA AND BREVE
+	0x1e1d: "e\u0327\u0306",            // LATIN SMALL LETTER E WITH CEDILLA AND BREVE
+	0x1e1e: "F\u0307",                  // LATIN CAPITAL LETTER F WITH DOT ABOVE
+	0x1e1f: "f\u0307",                  // LATIN SMALL LETTER F WITH DOT ABOVE
+	0x1e20: "G\u0304",                  // LATIN CAPITAL LETTER G WITH MACRON
+	0x1e21: "g\u0304",                  // LATIN SMALL LETTER G WITH MACRON
+	0x1e22: "H\u0307",                  // LATIN CAPITAL LETTER H WITH DOT ABOVE
+	0x1e23: "h\u0307",                  // LATIN SMALL LETTER H WITH DOT ABOVE
+	0x1e24: "H\u0323",                  // LATIN CAPITAL LETTER H WITH DOT BELOW
+	0x1e25: "h\u0323",                  // LATIN SMALL LETTER H WITH DOT BELOW
+	0x1e26: "H\u0308",                  // LATIN CAPITAL LETTER H WITH DIAERESIS
+	0x1e27: "h\u0308",                  // LATIN SMALL LETTER H WITH DIAERESIS
+	0x1e28: "H\u0327",                  // LATIN CAPITAL LETTER H WITH CEDILLA
+	0x1e29: "h\u0327",                  // LATIN SMALL LETTER H WITH CEDILLA
+	0x1e2a: "H\u032e",                  // LATIN CAPITAL LETTER H WITH BREVE BELOW
+	0x1e2b: "h\u032e",                  // LATIN SMALL LETTER H WITH BREVE BELOW
+	0x1e2e: "I\u0308\u0301",            // LATIN CAPITAL LETTER I WITH DIAERESIS AND ACUTE
+	0x1e2f: "i\u0308\u0301",            // LATIN SMALL LETTER I WITH DIAERESIS AND ACUTE
+	0x1e30: "K\u0301",                  // LATIN CAPITAL LETTER K WITH ACUTE
+	0x1e31: "k\u0301",                  // LATIN SMALL LETTER K WITH ACUTE
+	0x1e32: "K\u0323",                  // LATIN CAPITAL LETTER K WITH DOT BELOW
+	0x1e33: "k\u0323",                  // LATIN SMALL LETTER K WITH DOT BELOW
+	0x1e36: "L\u0323",                  // LATIN CAPITAL LETTER L WITH DOT BELOW
+	0x1e37: "l\u0323",                  // LATIN SMALL LETTER L WITH DOT BELOW
+	0x1e38: "L\u0323\u0304",            // LATIN CAPITAL LETTER L WITH DOT BELOW AND MACRON
+	0x1e39: "l\u0323\u0304",            // LATIN SMALL LETTER L WITH DOT BELOW AND MACRON
+	0x1e3e: "M\u0301",                  // LATIN CAPITAL LETTER M WITH ACUTE
+	0x1e3f: "m\u0301",                  // LATIN SMALL LETTER M WITH ACUTE
+	0x1e40: "M\u0307",                  // LATIN CAPITAL LETTER M WITH DOT ABOVE
+	0x1e41: "m\u0307",                  // LATIN SMALL LETTER M WITH DOT ABOVE
+	0x1e42: "M\u0323",                  // LATIN CAPITAL LETTER M WITH DOT BELOW
+	0x1e43: "m\u0323",                  // LATIN SMALL LETTER M WITH DOT BELOW
+	0x1e44: "N\u0307",                  // LATIN CAPITAL LETTER N WITH DOT ABOVE
+	0x1e45: "n\u0307",                  // LATIN SMALL LETTER N WITH DOT ABOVE
+	0x1e46: "N\u0323",                  // LATIN CAPITAL LETTER N WITH DOT BELOW
+	0x1e47: "n\u0323",                  // LATIN SMALL LETTER N WITH DOT BELOW
+	0x1e4c: "O\u0303\u0301",            // LATIN CAPITAL LETTER O WITH TILDE AND ACUTE
+	0x1e4d: "o\u0303\u0301",            // LATIN SMALL LETTER O WITH TILDE AND ACUTE
+	0x1e4e: "O\u0303\u0308",            // LATIN CAPITAL LETTER O WITH TILDE AND DIAERESIS
+	0x1e4f: "o\u0303\u0308",            // LATIN SMALL LETTER O WITH TILDE AND DIAERESIS
+	0x1e50: "O\u0304\u0300",            // LATIN CAPITAL LETTER O WITH MACRON AND GRAVE
+	0x1e51: "o\u0304\u0300",            // LATIN SMALL LETTER O WITH MACRON AND GRAVE
+	0x1e52: "O\u0304\u0301",            // LATIN CAPITAL LETTER O WITH MACRON AND ACUTE
+	0x1e53: "o\u0304\u0301",            // LATIN SMALL LETTER O WITH MACRON AND ACUTE
+	0x1e54: "P\u0301",                  // LATIN CAPITAL LETTER P WITH ACUTE
+	0x1e55: "p\u0301",                  // LATIN SMALL LETTER P WITH ACUTE
+	0x1e56: "P\u0307",                  // LATIN CAPITAL LETTER P WITH DOT ABOVE
+	0x1e57: "p\u0307",                  // LATIN SMALL LETTER P WITH DOT ABOVE
+	0x1e58: "R\u0307",                  // LATIN CAPITAL LETTER R WITH DOT ABOVE
+	0x1e59: "r\u0307",                  // LATIN SMALL LETTER R WITH DOT ABOVE
+	0x1e5a: "R\u0323",                  // LATIN CAPITAL LETTER R WITH DOT BELOW
+	0x1e5b: "r\u0323",                  // LATIN SMALL LETTER R WITH DOT BELOW
+	0x1e5c: "R\u0323\u0304",            // LATIN CAPITAL LETTER R WITH DOT BELOW AND MACRON
+	0x1e5d: "r\u0323\u0304",            // LATIN SMALL LETTER R WITH DOT BELOW AND MACRON
+	0x1e60: "S\u0307",                  // LATIN CAPITAL LETTER S WITH DOT ABOVE
+	0x1e61: "s\u0307",                  // LATIN SMALL LETTER S WITH DOT ABOVE
+	0x1e62: "S\u0323",                  // LATIN CAPITAL LETTER S WITH DOT BELOW
+	0x1e63: "s\u0323",                  // LATIN SMALL LETTER S WITH DOT BELOW
+	0x1e64: "S\u0301\u0307",            // LATIN CAPITAL LETTER S WITH ACUTE AND DOT ABOVE
+	0x1e65: "s\u0301\u0307",            // LATIN SMALL LETTER S WITH ACUTE AND DOT ABOVE
+	0x1e66: "S\u030c\u0307",            // LATIN CAPITAL LETTER S WITH CARON AND DOT ABOVE
+	0x1e67: "s\u030c\u0307",            // LATIN SMALL LETTER S WITH CARON AND DOT ABOVE
+	0x1e68: "S\u0323\u0307",            // LATIN CAPITAL LETTER S WITH DOT BELOW AND DOT ABOVE
+	0x1e69: "s\u0323\u0307",            // LATIN SMALL LETTER S WITH DOT BELOW AND DOT ABOVE
+	0x1e6a: "T\u0307",                  // LATIN CAPITAL LETTER T WITH DOT ABOVE
+	0x1e6b: "t\u0307",                  // LATIN SMALL LETTER T WITH DOT ABOVE
+	0x1e6c: "T\u0323",                  // LATIN CAPITAL LETTER T WITH DOT BELOW
+	0x1e6d: "t\u0323",                  // LATIN SMALL LETTER T WITH DOT BELOW
+	0x1e72: "U\u0324",                  // LATIN CAPITAL LETTER U WITH DIAERESIS BELOW
+	0x1e73: "u\u0324",                  // LATIN SMALL LETTER U WITH DIAERESIS BELOW
+	0x1e78: "U\u0303\u0301",            // LATIN CAPITAL LETTER U WITH TILDE AND ACUTE
+	0x1e79: "u\u0303\u0301",            // LATIN SMALL LETTER U WITH TILDE AND ACUTE
+	0x1e7a: "U\u0304\u0308",            // LATIN CAPITAL LETTER U WITH MACRON AND DIAERESIS
+	0x1e7b: "u\u0304\u0308",            // LATIN SMALL LETTER U WITH MACRON AND DIAERESIS
+	0x1e7c: "V\u0303",                  // LATIN CAPITAL LETTER V WITH TILDE
+	0x1e7d: "v\u0303",                  // LATIN SMALL LETTER V WITH TILDE
+	0x1e7e: "V\u0323",                  // LATIN CAPITAL LETTER V WITH DOT BELOW
+	0x1e7f: "v\u0323",                  // LATIN SMALL LETTER V WITH DOT BELOW
+	0x1e80: "W\u0300",                  // LATIN CAPITAL LETTER W WITH GRAVE
+	0x1e81: "w\u0300",                  // LATIN SMALL LETTER W WITH GRAVE
+	0x1e82: "W\u0301",                  // LATIN CAPITAL LETTER W WITH ACUTE
+	0x1e83: "w\u0301",                  // LATIN SMALL LETTER W WITH ACUTE
+	0x1e84: "W\u0308",                  // LATIN CAPITAL LETTER W WITH DIAERESIS
+	0x1e85: "w\u0308",                  // LATIN SMALL LETTER W WITH DIAERESIS
+	0x1e86: "W\u0307",                  // LATIN CAPITAL LETTER W WITH DOT ABOVE
+	0x1e87: "w\u0307",                  // LATIN SMALL LETTER W WITH DOT ABOVE
+	0x1e88: "W\u0323",                  // LATIN CAPITAL LETTER W WITH DOT BELOW
+	0x1e89: "w\u0323",                  // LATIN SMALL LETTER W WITH DOT BELOW
+	0x1e8a: "X\u0307",                  // LATIN CAPITAL LETTER X WITH DOT ABOVE
+	0x1e8b: "x\u0307",                  // LATIN SMALL LETTER X WITH DOT ABOVE
+	0x1e8c: "X\u0308",                  // LATIN CAPITAL LETTER X WITH DIAERESIS
+	0x1e8d: "x\u0308",                  // LATIN SMALL LETTER X WITH DIAERESIS
+	0x1e8e: "Y\u0307",                  // LATIN CAPITAL LETTER Y WITH DOT ABOVE
+	0x1e8f: "y\u0307",                  // LATIN SMALL LETTER Y WITH DOT ABOVE
+	0x1e90: "Z\u0302",                  // LATIN CAPITAL LETTER Z WITH CIRCUMFLEX
+	0x1e91: "z\u0302",                  // LATIN SMALL LETTER Z WITH CIRCUMFLEX
+	0x1e92: "Z\u0323",                  // LATIN CAPITAL LETTER Z WITH DOT BELOW
+	0x1e93: "z\u0323",                  // LATIN SMALL LETTER Z WITH DOT BELOW
+	0x1e97: "t\u0308",                  // LATIN SMALL LETTER T WITH DIAERESIS
+	0x1e98: "w\u030a",                  // LATIN SMALL LETTER W WITH RING ABOVE
+	0x1e99: "y\u030a",                  // LATIN SMALL LETTER Y WITH RING ABOVE
+	0x1ea0: "A\u0323",                  // LATIN CAPITAL LETTER A WITH DOT BELOW
+	0x1ea1: "a\u0323",                  // LATIN SMALL LETTER A WITH DOT BELOW
+	0x1ea2: "A\u0309",                  // LATIN CAPITAL LETTER A WITH HOOK ABOVE
+	0x1ea3: "a\u0309",                  // LATIN SMALL LETTER A WITH HOOK ABOVE
+	0x1ea4: "A\u0302\u0301",            // LATIN CAPITAL LETTER A WITH CIRCUMFLEX AND ACUTE
+	0x1ea5: "a\u0302\u0301",            // LATIN SMALL LETTER A WITH CIRCUMFLEX AND ACUTE
+	0x1ea6: "A\u0302\u0300",            // LATIN CAPITAL LETTER A WITH CIRCUMFLEX AND GRAVE
+	0x1ea7: "a\u0302\u0300",            // LATIN SMALL LETTER A WITH CIRCUMFLEX AND GRAVE
+	0x1ea8: "A\u0302\u0309",            // LATIN CAPITAL LETTER A WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1ea9: "a\u0302\u0309",            // LATIN SMALL LETTER A WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1eaa: "A\u0302\u0303",            // LATIN CAPITAL LETTER A WITH CIRCUMFLEX AND TILDE
+	0x1eab: "a\u0302\u0303",            // LATIN SMALL LETTER A WITH CIRCUMFLEX AND TILDE
+	0x1eac: "A\u0323\u0302",            // LATIN CAPITAL LETTER A WITH CIRCUMFLEX AND DOT BELOW
+	0x1ead: "a\u0323\u0302",            // LATIN SMALL LETTER A WITH CIRCUMFLEX AND DOT BELOW
+	0x1eae: "A\u0306\u0301",            // LATIN CAPITAL LETTER A WITH BREVE AND ACUTE
+	0x1eaf: "a\u0306\u0301",            // LATIN SMALL LETTER A WITH BREVE AND ACUTE
+	0x1eb0: "A\u0306\u0300",            // LATIN CAPITAL LETTER A WITH BREVE AND GRAVE
+	0x1eb1: "a\u0306\u0300",            // LATIN SMALL LETTER A WITH BREVE AND GRAVE
+	0x1eb2: "A\u0306\u0309",            // LATIN CAPITAL LETTER A WITH BREVE AND HOOK ABOVE
+	0x1eb3: "a\u0306\u0309",            // LATIN SMALL LETTER A WITH BREVE AND HOOK ABOVE
+	0x1eb4: "A\u0306\u0303",            // LATIN CAPITAL LETTER A WITH BREVE AND TILDE
+	0x1eb5: "a\u0306\u0303",            // LATIN SMALL LETTER A WITH BREVE AND TILDE
+	0x1eb6: "A\u0323\u0306",            // LATIN CAPITAL LETTER A WITH BREVE AND DOT BELOW
+	0x1eb7: "a\u0323\u0306",            // LATIN SMALL LETTER A WITH BREVE AND DOT BELOW
+	0x1eb8: "E\u0323",                  // LATIN CAPITAL LETTER E WITH DOT BELOW
+	0x1eb9: "e\u0323",                  // LATIN SMALL LETTER E WITH DOT BELOW
+	0x1eba: "E\u0309",                  // LATIN CAPITAL LETTER E WITH HOOK ABOVE
+	0x1ebb: "e\u0309",                  // LATIN SMALL LETTER E WITH HOOK ABOVE
+	0x1ebc: "E\u0303",                  // LATIN CAPITAL LETTER E WITH TILDE
+	0x1ebd: "e\u0303",                  // LATIN SMALL LETTER E WITH TILDE
+	0x1ebe: "E\u0302\u0301",            // LATIN CAPITAL LETTER E WITH CIRCUMFLEX AND ACUTE
+	0x1ebf: "e\u0302\u0301",            // LATIN SMALL LETTER E WITH CIRCUMFLEX AND ACUTE
+	0x1ec0: "E\u0302\u0300",            // LATIN CAPITAL LETTER E WITH CIRCUMFLEX AND GRAVE
+	0x1ec1: "e\u0302\u0300",            // LATIN SMALL LETTER E WITH CIRCUMFLEX AND GRAVE
+	0x1ec2: "E\u0302\u0309",            // LATIN CAPITAL LETTER E WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1ec3: "e\u0302\u0309",            // LATIN SMALL LETTER E WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1ec4: "E\u0302\u0303",            // LATIN CAPITAL LETTER E WITH CIRCUMFLEX AND TILDE
+	0x1ec5: "e\u0302\u0303",            // LATIN SMALL LETTER E WITH CIRCUMFLEX AND TILDE
+	0x1ec6: "E\u0323\u0302",            // LATIN CAPITAL LETTER E WITH CIRCUMFLEX AND DOT BELOW
+	0x1ec7: "e\u0323\u0302",            // LATIN SMALL LETTER E WITH CIRCUMFLEX AND DOT BELOW
+	0x1ec8: "I\u0309",                  // LATIN CAPITAL LETTER I WITH HOOK ABOVE
+	0x1ec9: "i\u0309",                  // LATIN SMALL LETTER I WITH HOOK ABOVE
+	0x1eca: "I\u0323",                  // LATIN CAPITAL LETTER I WITH DOT BELOW
+	0x1ecb: "i\u0323",                  // LATIN SMALL LETTER I WITH DOT BELOW
+	0x1ecc: "O\u0323",                  // LATIN CAPITAL LETTER O WITH DOT BELOW
+	0x1ecd: "o\u0323",                  // LATIN SMALL LETTER O WITH DOT BELOW
+	0x1ece: "O\u0309",                  // LATIN CAPITAL LETTER O WITH HOOK ABOVE
+	0x1ecf: "o\u0309",                  // LATIN SMALL LETTER O WITH HOOK ABOVE
+	0x1ed0: "O\u0302\u0301",            // LATIN CAPITAL LETTER O WITH CIRCUMFLEX AND ACUTE
+	0x1ed1: "o\u0302\u0301",            // LATIN SMALL LETTER O WITH CIRCUMFLEX AND ACUTE
+	0x1ed2: "O\u0302\u0300",            // LATIN CAPITAL LETTER O WITH CIRCUMFLEX AND GRAVE
+	0x1ed3: "o\u0302\u0300",            // LATIN SMALL LETTER O WITH CIRCUMFLEX AND GRAVE
+	0x1ed4: "O\u0302\u0309",            // LATIN CAPITAL LETTER O WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1ed5: "o\u0302\u0309",            // LATIN SMALL LETTER O WITH CIRCUMFLEX AND HOOK ABOVE
+	0x1ed6: "O\u0302\u0303",            // LATIN CAPITAL LETTER O WITH CIRCUMFLEX AND TILDE
+	0x1ed7: "o\u0302\u0303",            // LATIN SMALL LETTER O WITH CIRCUMFLEX AND TILDE
+	0x1ed8: "O\u0323\u0302",            // LATIN CAPITAL LETTER O WITH CIRCUMFLEX AND DOT BELOW
+	0x1ed9: "o\u0323\u0302",            // LATIN SMALL LETTER O WITH CIRCUMFLEX AND DOT BELOW
+	0x1ee4: "U\u0323",                  // LATIN CAPITAL LETTER U WITH DOT BELOW
+	0x1ee5: "u\u0323",                  // LATIN SMALL LETTER U WITH DOT BELOW
+	0x1ee6: "U\u0309",                  // LATIN CAPITAL LETTER U WITH HOOK ABOVE
+	0x1ee7: "u\u0309",                  // LATIN SMALL LETTER U WITH HOOK ABOVE
+	0x1ef2: "Y\u0300",                  // LATIN CAPITAL LETTER Y WITH GRAVE
+	0x1ef3: "y\u0300",                  // LATIN SMALL LETTER Y WITH GRAVE
+	0x1ef4: "Y\u0323",                  // LATIN CAPITAL LETTER Y WITH DOT BELOW
+	0x1ef5: "y\u0323",                  // LATIN SMALL LETTER Y WITH DOT BELOW
+	0x1ef6: "Y\u0309",                  // LATIN CAPITAL LETTER Y WITH HOOK ABOVE
+	0x1ef7: "y\u0309",                  // LATIN SMALL LETTER Y WITH HOOK ABOVE
+	0x1ef8: "Y\u0303",                  // LATIN CAPITAL LETTER Y WITH TILDE
+	0x1ef9: "y\u0303",                  // LATIN SMALL LETTER Y WITH TILDE
+	0x1f00: "\u03b1\u0313",             // GREEK SMALL LETTER ALPHA WITH PSILI
+	0x1f01: "\u03b1\u0314",             // GREEK SMALL LETTER ALPHA WITH DASIA
+	0x1f02: "\u03b1\u0313\u0300",       // GREEK SMALL LETTER ALPHA WITH PSILI AND VARIA
+	0x1f03: "\u03b1\u0314\u0300",       // GREEK SMALL LETTER ALPHA WITH DASIA AND VARIA
+	0x1f04: "\u03b1\u0313\u0301",       // GREEK SMALL LETTER ALPHA WITH PSILI AND OXIA
+	0x1f05: "\u03b1\u0314\u0301",       // GREEK SMALL LETTER ALPHA WITH DASIA AND OXIA
+	0x1f06: "\u03b1\u0313\u0342",       // GREEK SMALL LETTER ALPHA WITH PSILI AND PERISPOMENI
+	0x1f07: "\u03b1\u0314\u0342",       // GREEK SMALL LETTER ALPHA WITH DASIA AND PERISPOMENI
+	0x1f08: "\u0391\u0313",             // GREEK CAPITAL LETTER ALPHA WITH PSILI
+	0x1f09: "\u0391\u0314",             // GREEK CAPITAL LETTER ALPHA WITH DASIA
+	0x1f0a: "\u0391\u0313\u0300",       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND VARIA
+	0x1f0b: "\u0391\u0314\u0300",       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND VARIA
+	0x1f0c: "\u0391\u0313\u0301",       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND OXIA
+	0x1f0d: "\u0391\u0314\u0301",       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND OXIA
+	0x1f0e: "\u0391\u0313\u0342",       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PERISPOMENI
+	0x1f0f: "\u0391\u0314\u0342",       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PERISPOMENI
+	0x1f10: "\u03b5\u0313",             // GREEK SMALL LETTER EPSILON WITH PSILI
+	0x1f11: "\u03b5\u0314",             // GREEK SMALL LETTER EPSILON WITH DASIA
+	0x1f12: "\u03b5\u0313\u0300",       // GREEK SMALL LETTER EPSILON WITH PSILI AND VARIA
+	0x1f13: "\u03b5\u0314\u0300",       // GREEK SMALL LETTER EPSILON WITH DASIA AND VARIA
+	0x1f14: "\u03b5\u0313\u0301",       // GREEK SMALL LETTER EPSILON WITH PSILI AND OXIA
+	0x1f15: "\u03b5\u0314\u0301",       // GREEK SMALL LETTER EPSILON WITH DASIA AND OXIA
+	0x1f18: "\u0395\u0313",             // GREEK CAPITAL LETTER EPSILON WITH PSILI
+	0x1f19: "\u0395\u0314",             // GREEK CAPITAL LETTER EPSILON WITH DASIA
+	0x1f1a: "\u0395\u0313\u0300",       // GREEK CAPITAL LETTER EPSILON WITH PSILI AND VARIA
+	0x1f1b: "\u0395\u0314\u0300",       // GREEK CAPITAL LETTER EPSILON WITH DASIA AND VARIA
+	0x1f1c: "\u0395\u0313\u0301",       // GREEK CAPITAL LETTER EPSILON WITH PSILI AND OXIA
+	0x1f1d: "\u0395\u0314\u0301",       // GREEK CAPITAL LETTER EPSILON WITH DASIA AND OXIA
+	0x1f20: "\u03b7\u0313",             // GREEK SMALL LETTER ETA WITH PSILI
+	0x1f21: "\u03b7\u0314",             // GREEK SMALL LETTER ETA WITH DASIA
+	0x1f22: "\u03b7\u0313\u0300",       // GREEK SMALL LETTER ETA WITH PSILI AND VARIA
+	0x1f23: "\u03b7\u0314\u0300",       // GREEK SMALL LETTER ETA WITH DASIA AND VARIA
+	0x1f24: "\u03b7\u0313\u0301",       // GREEK SMALL LETTER ETA WITH PSILI AND OXIA
+	0x1f25: "\u03b7\u0314\u0301",       // GREEK SMALL LETTER ETA WITH DASIA AND OXIA
+	0x1f26: "\u03b7\u0313\u0342",       // GREEK SMALL LETTER ETA WITH PSILI AND PERISPOMENI
+	0x1f27: "\u03b7\u0314\u0342",       // GREEK SMALL LETTER ETA WITH DASIA AND PERISPOMENI
+	0x1f28: "\u0397\u0313",             // GREEK CAPITAL LETTER ETA WITH PSILI
+	0x1f29: "\u0397\u0314",             // GREEK CAPITAL LETTER ETA WITH DASIA
+	0x1f2a: "\u0397\u0313\u0300",       // GREEK CAPITAL LETTER ETA WITH PSILI AND VARIA
+	0x1f2b: "\u0397\u0314\u0300",       // GREEK CAPITAL LETTER ETA WITH DASIA AND VARIA
+	0x1f2c: "\u0397\u0313\u0301",       // GREEK CAPITAL LETTER ETA WITH PSILI AND OXIA
+	0x1f2d: "\u0397\u0314\u0301",       // GREEK CAPITAL LETTER ETA WITH DASIA AND OXIA
+	0x1f2e: "\u0397\u0313\u0342",       // GREEK CAPITAL LETTER ETA WITH PSILI AND PERISPOMENI
+	0x1f2f: "\u0397\u0314\u0342",       // GREEK CAPITAL LETTER ETA WITH DASIA AND PERISPOMENI
+	0x1f30: "\u03b9\u0313",             // GREEK SMALL LETTER IOTA WITH PSILI
+	0x1f31: "\u03b9\u0314",             // GREEK SMALL LETTER IOTA WITH DASIA
+	0x1f32: "\u03b9\u0313\u0300",       // GREEK SMALL LETTER IOTA WITH PSILI AND VARIA
+	0x1f33: "\u03b9\u0314\u0300",       // GREEK SMALL LETTER IOTA WITH DASIA AND VARIA
+	0x1f34: "\u03b9\u0313\u0301",       // GREEK SMALL LETTER IOTA WITH PSILI AND OXIA
+	0x1f35: "\u03b9\u0314\u0301",       // GREEK SMALL LETTER IOTA WITH DASIA AND OXIA
+	0x1f36: "\u03b9\u0313\u0342",       // GREEK SMALL LETTER IOTA WITH PSILI AND PERISPOMENI
+	0x1f37: "\u03b9\u0314\u0342",       // GREEK SMALL LETTER IOTA WITH DASIA AND PERISPOMENI
+	0x1f38: "\u0399\u0313",             // GREEK CAPITAL LETTER IOTA WITH PSILI
+	0x1f39: "\u0399\u0314",             // GREEK CAPITAL LETTER IOTA WITH DASIA
+	0x1f3a: "\u0399\u0313\u0300",       // GREEK CAPITAL LETTER IOTA WITH PSILI AND VARIA
+	0x1f3b: "\u0399\u0314\u0300",       // GREEK CAPITAL LETTER IOTA WITH DASIA AND VARIA
+	0x1f3c: "\u0399\u0313\u0301",       // GREEK CAPITAL LETTER IOTA WITH PSILI AND OXIA
+	0x1f3d: "\u0399\u0314\u0301",       // GREEK CAPITAL LETTER IOTA WITH DASIA AND OXIA
+	0x1f3e: "\u0399\u0313\u0342",       // GREEK CAPITAL LETTER IOTA WITH PSILI AND PERISPOMENI
+	0x1f3f: "\u0399\u0314\u0342",       // GREEK CAPITAL LETTER IOTA WITH DASIA AND PERISPOMENI
+	0x1f40: "\u03bf\u0313",             // GREEK SMALL LETTER OMICRON WITH PSILI
+	0x1f41: "\u03bf\u0314",             // GREEK SMALL LETTER OMICRON WITH DASIA
+	0x1f42: "\u03bf\u0313\u0300",       // GREEK SMALL LETTER OMICRON WITH PSILI AND VARIA
+	0x1f43: "\u03bf\u0314\u0300",       // GREEK SMALL LETTER OMICRON WITH DASIA AND VARIA
+	0x1f44: "\u03bf\u0313\u0301",       // GREEK SMALL LETTER OMICRON WITH PSILI AND OXIA
+	0x1f45: "\u03bf\u0314\u0301",       // GREEK SMALL LETTER OMICRON WITH DASIA AND OXIA
+	0x1f48: "\u039f\u0313",             // GREEK CAPITAL LETTER OMICRON WITH PSILI
+	0x1f49: "\u039f\u0314",             // GREEK CAPITAL LETTER OMICRON WITH DASIA
+	0x1f4a: "\u039f\u0313\u0300",       // GREEK CAPITAL LETTER OMICRON WITH PSILI AND VARIA
+	0x1f4b: "\u039f\u0314\u0300",       // GREEK CAPITAL LETTER OMICRON WITH DASIA AND VARIA
+	0x1f4c: "\u039f\u0313\u0301",       // GREEK CAPITAL LETTER OMICRON WITH PSILI AND OXIA
+	0x1f4d: "\u039f\u0314\u0301",       // GREEK CAPITAL LETTER OMICRON WITH DASIA AND OXIA
+	0x1f50: "\u03c5\u0313",             // GREEK SMALL LETTER UPSILON WITH PSILI
+	0x1f51: "\u03c5\u0314",             // GREEK SMALL LETTER UPSILON WITH DASIA
+	0x1f52: "\u03c5\u0313\u0300",       // GREEK SMALL LETTER UPSILON WITH PSILI AND VARIA
+	0x1f53: "\u03c5\u0314\u0300",       // GREEK SMALL LETTER UPSILON WITH DASIA AND VARIA
+	0x1f54: "\u03c5\u0313\u0301",       // GREEK SMALL LETTER UPSILON WITH PSILI AND OXIA
+	0x1f55: "\u03c5\u0314\u0301",       // GREEK SMALL LETTER UPSILON WITH DASIA AND OXIA
+	0x1f56: "\u03c5\u0313\u0342",       // GREEK SMALL LETTER UPSILON WITH PSILI AND PERISPOMENI
+	0x1f57: "\u03c5\u0314\u0342",       // GREEK SMALL LETTER UPSILON WITH DASIA AND PERISPOMENI
+	0x1f59: "\u03a5\u0314",             // GREEK CAPITAL LETTER UPSILON WITH DASIA
+	0x1f5b: "\u03a5\u0314\u0300",       // GREEK CAPITAL LETTER UPSILON WITH DASIA AND VARIA
+	0x1f5d: "\u03a5\u0314\u0301",       // GREEK CAPITAL LETTER UPSILON WITH DASIA AND OXIA
+	0x1f5f: "\u03a5\u0314\u0342",       // GREEK CAPITAL LETTER UPSILON WITH DASIA AND PERISPOMENI
+	0x1f60: "\u03c9\u0313",             // GREEK SMALL LETTER OMEGA WITH PSILI
+	0x1f61: "\u03c9\u0314",             // GREEK SMALL LETTER OMEGA WITH DASIA
+	0x1f62: "\u03c9\u0313\u0300",       // GREEK SMALL LETTER OMEGA WITH PSILI AND VARIA
+	0x1f63: "\u03c9\u0314\u0300",       // GREEK SMALL LETTER OMEGA WITH DASIA AND VARIA
+	0x1f64: "\u03c9\u0313\u0301",       // GREEK SMALL LETTER OMEGA WITH PSILI AND OXIA
+	0x1f65: "\u03c9\u0314\u0301",       // GREEK SMALL LETTER OMEGA WITH DASIA AND OXIA
+	0x1f66: "\u03c9\u0313\u0342",       // GREEK SMALL LETTER OMEGA WITH PSILI AND PERISPOMENI
+	0x1f67: "\u03c9\u0314\u0342",       // GREEK SMALL LETTER OMEGA WITH DASIA AND PERISPOMENI
+	0x1f68: "\u03a9\u0313",             // GREEK CAPITAL LETTER OMEGA WITH PSILI
+	0x1f69: "\u03a9\u0314",             // GREEK CAPITAL LETTER OMEGA WITH DASIA
+	0x1f6a: "\u03a9\u0313\u0300",       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND VARIA
+	0x1f6b: "\u03a9\u0314\u0300",       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND VARIA
+	0x1f6c: "\u03a9\u0313\u0301",       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND OXIA
+	0x1f6d: "\u03a9\u0314\u0301",       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND OXIA
+	0x1f6e: "\u03a9\u0313\u0342",       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PERISPOMENI
+	0x1f6f: "\u03a9\u0314\u0342",       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PERISPOMENI
+	0x1f70: "\u03b1\u0300",             // GREEK SMALL LETTER ALPHA WITH VARIA
+	0x1f71: "\u03b1\u0301",             // GREEK SMALL LETTER ALPHA WITH OXIA
+	0x1f72: "\u03b5\u0300",             // GREEK SMALL LETTER EPSILON WITH VARIA
+	0x1f73: "\u03b5\u0301",             // GREEK SMALL LETTER EPSILON WITH OXIA
+	0x1f74: "\u03b7\u0300",             // GREEK SMALL LETTER ETA WITH VARIA
+	0x1f75: "\u03b7\u0301",             // GREEK SMALL LETTER ETA WITH OXIA
+	0x1f76: "\u03b9\u0300",             // GREEK SMALL LETTER IOTA WITH VARIA
+	0x1f77: "\u03b9\u0301",             // GREEK SMALL LETTER IOTA WITH OXIA
+	0x1f78: "\u03bf\u0300",             // GREEK SMALL LETTER OMICRON WITH VARIA
+	0x1f79: "\u03bf\u0301",             // GREEK SMALL LETTER OMICRON WITH OXIA
+	0x1f7a: "\u03c5\u0300",             // GREEK SMALL LETTER UPSILON WITH VARIA
+	0x1f7b: "\u03c5\u0301",             // GREEK SMALL LETTER UPSILON WITH OXIA
+	0x1f7c: "\u03c9\u0300",             // GREEK SMALL LETTER OMEGA WITH VARIA
+	0x1f7d: "\u03c9\u0301",             // GREEK SMALL LETTER OMEGA WITH OXIA
+	0x1f80: "\u03b1\u0313\u0345",       // GREEK SMALL LETTER ALPHA WITH PSILI AND YPOGEGRAMMENI
+	0x1f81: "\u03b1\u0314\u0345",       // GREEK SMALL LETTER ALPHA WITH DASIA AND YPOGEGRAMMENI
+	0x1f82: "\u03b1\u0313\u0300\u0345", // GREEK SMALL LETTER ALPHA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	0x1f83: "\u03b1\u0314\u0300\u0345", // GREEK SMALL LETTER ALPHA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	0x1f84: "\u03b1\u0313\u0301\u0345", // GREEK SMALL LETTER ALPHA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	0x1f85: "\u03b1\u0314\u0301\u0345", // GREEK SMALL LETTER ALPHA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	0x1f86: "\u03b1\u0313\u0342\u0345", // GREEK SMALL LETTER ALPHA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1f87: "\u03b1\u0314\u0342\u0345", // GREEK SMALL LETTER ALPHA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1f88: "\u0391\u0313\u0345",       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PROSGEGRAMMENI
+	0x1f89: "\u0391\u0314\u0345",       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PROSGEGRAMMENI
+	0x1f8a: "\u0391\u0313\u0300\u0345", // GREEK CAPITAL LETTER ALPHA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	0x1f8b: "\u0391\u0314\u0300\u0345", // GREEK CAPITAL LETTER ALPHA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	0x1f8c: "\u0391\u0313\u0301\u0345", // GREEK CAPITAL LETTER ALPHA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	0x1f8d: "\u0391\u0314\u0301\u0345", // GREEK CAPITAL LETTER ALPHA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	0x1f8e: "\u0391\u0313\u0342\u0345", // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1f8f: "\u0391\u0314\u0342\u0345", // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1f90: "\u03b7\u0313\u0345",       // GREEK SMALL LETTER ETA WITH PSILI AND YPOGEGRAMMENI
+	0x1f91: "\u03b7\u0314\u0345",       // GREEK SMALL LETTER ETA WITH DASIA AND YPOGEGRAMMENI
+	0x1f92: "\u03b7\u0313\u0300\u0345", // GREEK SMALL LETTER ETA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	0x1f93: "\u03b7\u0314\u0300\u0345", // GREEK SMALL LETTER ETA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	0x1f94: "\u03b7\u0313\u0301\u0345", // GREEK SMALL LETTER ETA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	0x1f95: "\u03b7\u0314\u0301\u0345", // GREEK SMALL LETTER ETA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	0x1f96: "\u03b7\u0313\u0342\u0345", // GREEK SMALL LETTER ETA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1f97: "\u03b7\u0314\u0342\u0345", // GREEK SMALL LETTER ETA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1f98: "\u0397\u0313\u0345",       // GREEK CAPITAL LETTER ETA WITH PSILI AND PROSGEGRAMMENI
+	0x1f99: "\u0397\u0314\u0345",       // GREEK CAPITAL LETTER ETA WITH DASIA AND PROSGEGRAMMENI
+	0x1f9a: "\u0397\u0313\u0300\u0345", // GREEK CAPITAL LETTER ETA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	0x1f9b: "\u0397\u0314\u0300\u0345", // GREEK CAPITAL LETTER ETA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	0x1f9c: "\u0397\u0313\u0301\u0345", // GREEK CAPITAL LETTER ETA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	0x1f9d: "\u0397\u0314\u0301\u0345", // GREEK CAPITAL LETTER ETA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	0x1f9e: "\u0397\u0313\u0342\u0345", // GREEK CAPITAL LETTER ETA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1f9f: "\u0397\u0314\u0342\u0345", // GREEK CAPITAL LETTER ETA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1fa0: "\u03c9\u0313\u0345",       // GREEK SMALL LETTER OMEGA WITH PSILI AND YPOGEGRAMMENI
+	0x1fa1: "\u03c9\u0314\u0345",       // GREEK SMALL LETTER OMEGA WITH DASIA AND YPOGEGRAMMENI
+	0x1fa2: "\u03c9\u0313\u0300\u0345", // GREEK SMALL LETTER OMEGA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	0x1fa3: "\u03c9\u0314\u0300\u0345", // GREEK SMALL LETTER OMEGA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	0x1fa4: "\u03c9\u0313\u0301\u0345", // GREEK SMALL LETTER OMEGA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	0x1fa5: "\u03c9\u0314\u0301\u0345", // GREEK SMALL LETTER OMEGA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	0x1fa6: "\u03c9\u0313\u0342\u0345", // GREEK SMALL LETTER OMEGA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1fa7: "\u03c9\u0314\u0342\u0345", // GREEK SMALL LETTER OMEGA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	0x1fa8: "\u03a9\u0313\u0345",       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PROSGEGRAMMENI
+	0x1fa9: "\u03a9\u0314\u0345",       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PROSGEGRAMMENI
+	0x1faa: "\u03a9\u0313\u0300\u0345", // GREEK CAPITAL LETTER OMEGA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	0x1fab: "\u03a9\u0314\u0300\u0345", // GREEK CAPITAL LETTER OMEGA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	0x1fac: "\u03a9\u0313\u0301\u0345", // GREEK CAPITAL LETTER OMEGA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	0x1fad: "\u03a9\u0314\u0301\u0345", // GREEK CAPITAL LETTER OMEGA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	0x1fae: "\u03a9\u0313\u0342\u0345", // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1faf: "\u03a9\u0314\u0342\u0345", // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	0x1fb0: "\u03b1\u0306",             // GREEK SMALL LETTER ALPHA WITH VRACHY
+	0x1fb1: "\u03b1\u0304",             // GREEK SMALL LETTER ALPHA WITH MACRON
+	0x1fb2: "\u03b1\u0300\u0345",       // GREEK SMALL LETTER ALPHA WITH VARIA AND YPOGEGRAMMENI
+	0x1fb3: "\u03b1\u0345",             // GREEK SMALL LETTER ALPHA WITH YPOGEGRAMMENI
+	0x1fb4: "\u03b1\u0301\u0345",       // GREEK SMALL LETTER ALPHA WITH OXIA AND YPOGEGRAMMENI
+	0x1fb6: "\u03b1\u0342",             // GREEK SMALL LETTER ALPHA WITH PERISPOMENI
+	0x1fb7: "\u03b1\u0342\u0345",       // GREEK SMALL LETTER ALPHA WITH PERISPOMENI AND YPOGEGRAMMENI
+	0x1fb8: "\u0391\u0306",             // GREEK CAPITAL LETTER ALPHA WITH VRACHY
+	0x1fb9: "\u0391\u0304",             // GREEK CAPITAL LETTER ALPHA WITH MACRON
+	0x1fba: "\u0391\u0300",             // GREEK CAPITAL LETTER ALPHA WITH VARIA
+	0x1fbb: "\u0391\u0301",             // GREEK CAPITAL LETTER ALPHA WITH OXIA
+	0x1fbc: "\u0391\u0345",             // GREEK CAPITAL LETTER ALPHA WITH PROSGEGRAMMENI
+	0x1fbe: "\u03b9",                   // GREEK PROSGEGRAMMENI
+	0x1fc2: "\u03b7\u0300\u0345",       // GREEK SMALL LETTER ETA WITH VARIA AND YPOGEGRAMMENI
+	0x1fc3: "\u03b7\u0345",             // GREEK SMALL LETTER ETA WITH YPOGEGRAMMENI
+	0x1fc4: "\u03b7\u0301\u0345",       // GREEK SMALL LETTER ETA WITH OXIA AND YPOGEGRAMMENI
+	0x1fc6: "\u03b7\u0342",             // GREEK SMALL LETTER ETA WITH PERISPOMENI
+	0x1fc7: "\u03b7\u0342\u0345",       // GREEK SMALL LETTER ETA WITH PERISPOMENI AND YPOGEGRAMMENI
+	0x1fc8: "\u0395\u0300",             // GREEK CAPITAL LETTER EPSILON WITH VARIA
+	0x1fc9: "\u0395\u0301",             // GREEK CAPITAL LETTER EPSILON WITH OXIA
+	0x1fca: "\u0397\u0300",             // GREEK CAPITAL LETTER ETA WITH VARIA
+	0x1fcb: "\u0397\u0301",             // GREEK CAPITAL LETTER ETA WITH OXIA
+	0x1fcc: "\u0397\u0345",             // GREEK CAPITAL LETTER ETA WITH PROSGEGRAMMENI
+	0x1fd0: "\u03b9\u0306",             // GREEK SMALL LETTER IOTA WITH VRACHY
+	0x1fd1: "\u03b9\u0304",             // GREEK SMALL LETTER IOTA WITH MACRON
+	0x1fd2: "\u03b9\u0308\u0300",       // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND VARIA
+	0x1fd3: "\u03b9\u0308\u0301",       // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND OXIA
+	0x1fd6: "\u03b9\u0342",             // GREEK SMALL LETTER IOTA WITH PERISPOMENI
+	0x1fd7: "\u03b9\u0308\u0342",       // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND PERISPOMENI
+	0x1fd8: "\u0399\u0306",             // GREEK CAPITAL LETTER IOTA WITH VRACHY
+	0x1fd9: "\u0399\u0304",             // GREEK CAPITAL LETTER IOTA WITH MACRON
+	0x1fda: "\u0399\u0300",             // GREEK CAPITAL LETTER IOTA WITH VARIA
+	0x1fdb: "\u0399\u0301",             // GREEK CAPITAL LETTER IOTA WITH OXIA
+	0x1fe0: "\u03c5\u0306",             // GREEK SMALL LETTER UPSILON WITH VRACHY
+	0x1fe1: "\u03c5\u0304",             // GREEK SMALL LETTER UPSILON WITH MACRON
+	0x1fe2: "\u03c5\u0308\u0300",       // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND VARIA
+	0x1fe3: "\u03c5\u0308\u0301",       // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND OXIA
+	0x1fe4: "\u03c1\u0313",             // GREEK SMALL LETTER RHO WITH PSILI
+	0x1fe5: "\u03c1\u0314",             // GREEK SMALL LETTER RHO WITH DASIA
+	0x1fe6: "\u03c5\u0342",             // GREEK SMALL LETTER UPSILON WITH PERISPOMENI
+	0x1fe7: "\u03c5\u0308\u0342",       // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND PERISPOMENI
+	0x1fe8: "\u03a5\u0306",             // GREEK CAPITAL LETTER UPSILON WITH VRACHY
+	0x1fe9: "\u03a5\u0304",             // GREEK CAPITAL LETTER UPSILON WITH MACRON
+	0x1fea: "\u03a5\u0300",             // GREEK CAPITAL LETTER UPSILON WITH VARIA
+	0x1feb: "\u03a5\u0301",             // GREEK CAPITAL LETTER UPSILON WITH OXIA
+	0x1fec: "\u03a1\u0314",             // GREEK CAPITAL LETTER RHO WITH DASIA
+	0x1fef: "`",                        // GREEK VARIA
+	0x1ff2: "\u03c9\u0300\u0345",       // GREEK SMALL LETTER OMEGA WITH VARIA AND YPOGEGRAMMENI
+	0x1ff3: "\u03c9\u0345",             // GREEK SMALL LETTER OMEGA WITH YPOGEGRAMMENI
+	0x1ff4: "\u03c9\u0301\u0345",       // GREEK SMALL LETTER OMEGA WITH OXIA AND YPOGEGRAMMENI
+	0x1ff6: "\u03c9\u0342",             // GREEK SMALL LETTER OMEGA WITH PERISPOMENI
+	0x1ff7: "\u03c9\u0342\u0345",       // GREEK SMALL LETTER OMEGA WITH PERISPOMENI AND YPOGEGRAMMENI
+	0x1ff8: "\u039f\u0300",             // GREEK CAPITAL LETTER OMICRON WITH VARIA
+	0x1ff9: "\u039f\u0301",             // GREEK CAPITAL LETTER OMICRON WITH OXIA
+	0x1ffa: "\u03a9\u0300",             // GREEK CAPITAL LETTER OMEGA WITH VARIA
+	0x1ffb: "\u03a9\u0301",             // GREEK CAPITAL LETTER OMEGA WITH OXIA
+	0x1ffc: "\u03a9\u0345",             // GREEK CAPITAL LETTER OMEGA WITH PROSGEGRAMMENI
+}
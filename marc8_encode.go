@@ -0,0 +1,291 @@
+package marc21
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// setOrder is the order in which character sets are considered, when a
+// character is contained in more than one set.
+var setOrder = []byte{
+	setBasicLatin, setExtendedLatin, setBasicGreek, setBasicCyrillic,
+	setExtCyrillic, setBasicHebrew, setBasicArabic, setExtArabic,
+	setGreekSymbols, setSubscripts, setSuperscripts, setEACC,
+}
+
+// charLocation is the position of a character in a character set.
+type charLocation struct {
+	cs   *charset
+	code int
+}
+
+// MARC8Encoder converts UTF-8 values to MARC-8. Precomposed characters are
+// decomposed and combining characters are placed before their base
+// character, as required by MARC-8. Escape sequences are written for
+// characters outside of Basic and Extended Latin; each value ends with the
+// default character sets designated again.
+type MARC8Encoder struct {
+	// Substitute is written in place of characters that cannot be
+	// represented in MARC-8. If empty, the numeric character reference
+	// convention of the MARC 21 specification (&#xXXXX;) is used.
+	Substitute string
+
+	index map[rune]charLocation
+}
+
+// NewMARC8Encoder returns an encoder for the currently known character sets,
+// including sets added with LoadMARC8Tables.
+func NewMARC8Encoder() *MARC8Encoder {
+	var finals []byte
+	seen := make(map[byte]bool)
+	for _, f := range setOrder {
+		finals = append(finals, f)
+		seen[f] = true
+	}
+	var extra []byte
+	for f := range charsets {
+		if !seen[f] {
+			extra = append(extra, f)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	finals = append(finals, extra...)
+
+	index := make(map[rune]charLocation)
+	for _, f := range finals {
+		cs := charsets[f]
+		codes := make([]int, 0, len(cs.chars))
+		for code := range cs.chars {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			r := cs.chars[code]
+			if _, ok := index[r]; !ok {
+				index[r] = charLocation{cs: cs, code: code}
+			}
+		}
+	}
+	return &MARC8Encoder{index: index}
+}
+
+// isTechniqueOne returns true for sets, that are designated with a single
+// character after ESC.
+func isTechniqueOne(cs *charset) bool {
+	switch cs.final {
+	case setGreekSymbols, setSubscripts, setSuperscripts:
+		return true
+	}
+	return false
+}
+
+// isG1 returns true for sets, that are designated as G1.
+func isG1(cs *charset) bool {
+	switch cs.final {
+	case setExtendedLatin, setExtCyrillic, setExtArabic:
+		return true
+	}
+	return false
+}
+
+// marc8State keeps track of the designated sets while encoding.
+type marc8State struct {
+	g0, g1 *charset
+	buf    strings.Builder
+	lossy  bool
+}
+
+// writeCode writes a code of a designated set.
+func (st *marc8State) writeCode(cs *charset, code int, high byte) {
+	if cs.multibyte {
+		st.buf.WriteByte(byte(code>>16) | high)
+		st.buf.WriteByte(byte(code>>8) | high)
+	}
+	st.buf.WriteByte(byte(code) | high)
+}
+
+// designate writes the escape sequence designating a set.
+func (st *marc8State) designate(cs *charset) {
+	switch {
+	case isTechniqueOne(cs):
+		st.buf.WriteString(string([]byte{ESC, cs.final}))
+		st.g0 = cs
+	case cs.multibyte:
+		st.buf.WriteString(string([]byte{ESC, '$', cs.final}))
+		st.g0 = cs
+	case isG1(cs):
+		st.buf.WriteString(string([]byte{ESC, ')', cs.final}))
+		st.g1 = cs
+	default:
+		st.buf.WriteString(string([]byte{ESC, '(', cs.final}))
+		st.g0 = cs
+	}
+}
+
+// reset designates the default sets again.
+func (st *marc8State) reset() {
+	basic, extended := charsets[setBasicLatin], charsets[setExtendedLatin]
+	if st.g0 != basic {
+		if isTechniqueOne(st.g0) {
+			st.buf.WriteString(string([]byte{ESC, 's'}))
+		} else {
+			st.buf.WriteString(string([]byte{ESC, '(', setBasicLatin}))
+		}
+		st.g0 = basic
+	}
+	if st.g1 != extended {
+		st.buf.WriteString(string([]byte{ESC, ')', setExtendedLatin}))
+		st.g1 = extended
+	}
+}
+
+// encodeRune writes a single character, switching sets as needed.
+func (e *MARC8Encoder) encodeRune(st *marc8State, r rune) {
+	if r == ' ' || r < 0x20 || r == 0x7f {
+		st.buf.WriteByte(byte(r))
+		return
+	}
+	loc, ok := e.index[r]
+	if !ok {
+		st.lossy = true
+		if e.Substitute != "" {
+			st.buf.WriteString(e.Substitute)
+		} else {
+			for _, c := range fmt.Sprintf("&#x%04X;", r) {
+				e.encodeRune(st, c)
+			}
+		}
+		return
+	}
+	// Prefer the designated sets, if they contain the character.
+	if code, ok := st.g0.codes[r]; ok {
+		st.writeCode(st.g0, code, 0)
+		return
+	}
+	if code, ok := st.g1.codes[r]; ok {
+		st.writeCode(st.g1, code, 0x80)
+		return
+	}
+	st.designate(loc.cs)
+	if loc.cs == st.g0 {
+		st.writeCode(loc.cs, loc.code, 0)
+	} else {
+		st.writeCode(loc.cs, loc.code, 0x80)
+	}
+}
+
+// isCombining returns true, if the rune is a combining character.
+func (e *MARC8Encoder) isCombining(r rune) bool {
+	if loc, ok := e.index[r]; ok && loc.cs.combining[loc.code] {
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// decompose decomposes all characters of a string, that are not contained in
+// a MARC-8 character set as they are.
+func (e *MARC8Encoder) decompose(s string) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		if _, ok := e.index[r]; !ok {
+			if d, ok := decompositions[r]; ok {
+				runes = append(runes, []rune(d)...)
+				continue
+			}
+		}
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// Encode converts a UTF-8 value to MARC-8. It returns true as second value,
+// if some characters had to be substituted.
+func (e *MARC8Encoder) Encode(s string) (string, bool) {
+	st := &marc8State{g0: charsets[setBasicLatin], g1: charsets[setExtendedLatin]}
+	runes := e.decompose(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && e.isCombining(runes[j]) {
+			j++
+		}
+		for _, r := range runes[i+1 : j] {
+			e.encodeRune(st, r)
+		}
+		e.encodeRune(st, runes[i])
+		i = j
+	}
+	st.reset()
+	return st.buf.String(), st.lossy
+}
+
+// encodeField returns a MARC-8 encoded copy of a field and whether the
+// conversion lost characters.
+func (e *MARC8Encoder) encodeField(f Field) (Field, bool) {
+	switch field := f.(type) {
+	case *ControlField:
+		v, lossy := e.Encode(field.Data)
+		return &ControlField{Tag: field.Tag, Data: v, raw: field.raw, dirty: field.dirty}, lossy
+	case *DataField:
+		out := &DataField{Tag: field.Tag, Ind1: field.Ind1, Ind2: field.Ind2, raw: field.raw, dirty: field.dirty}
+		var lossy bool
+		for _, sf := range field.SubFields {
+			v, l := e.Encode(sf.Value)
+			lossy = lossy || l
+			out.SubFields = append(out.SubFields, &SubField{Code: sf.Code, Value: v})
+		}
+		return out, lossy
+	}
+	return f, false
+}
+
+// encodeRecord returns a MARC-8 encoded copy of a record, along with the
+// fields of the original record that could not be converted without loss.
+func (e *MARC8Encoder) encodeRecord(record *Record) (*Record, []Field) {
+	leader := defaultLeader()
+	if record.Leader != nil {
+		*leader = *record.Leader
+	}
+	leader.CharacterEncoding = ' '
+	out := &Record{Leader: leader, Fields: make([]Field, 0, len(record.Fields))}
+	indicators, _, _, _ := leader.entryMap()
+	var lossy []Field
+	for _, f := range record.Fields {
+		if raw := rawMARC8Field(f, indicators); raw != nil {
+			out.Fields = append(out.Fields, raw)
+			continue
+		}
+		encoded, l := e.encodeField(f)
+		if l {
+			lossy = append(lossy, f)
+		}
+		out.Fields = append(out.Fields, encoded)
+	}
+	return out, lossy
+}
+
+// EncodeRecord converts all values of a UTF-8 record to MARC-8 in place and
+// sets the character coding scheme in the leader (position 09) to blank. It
+// returns the fields that could not be converted without loss. Records,
+// whose leader indicates MARC-8 already, are not changed.
+func (e *MARC8Encoder) EncodeRecord(record *Record) (lossy []Field) {
+	if record.Leader != nil && record.Leader.CharacterEncoding != 'a' {
+		return nil
+	}
+	encoded, lossy := e.encodeRecord(record)
+	for i, f := range record.Fields {
+		switch field := f.(type) {
+		case *ControlField:
+			field.Data = encoded.Fields[i].(*ControlField).Data
+		case *DataField:
+			for j, sf := range field.SubFields {
+				sf.Value = encoded.Fields[i].(*DataField).SubFields[j].Value
+			}
+		}
+	}
+	if record.Leader != nil {
+		record.Leader.CharacterEncoding = ' '
+	}
+	return lossy
+}
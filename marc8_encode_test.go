@@ -0,0 +1,128 @@
+package marc21
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMARC8EncodeRoundtrip(t *testing.T) {
+	var cases = []string{
+		"Arithmetic /",
+		"Céleste dragon",
+		"Upravlenie neĭtronnym polem i︠a︡dernogo reaktora /",
+		"Érintések",
+		"Łódź, ß & æ",
+		"Тихий Дон.",
+		"Ёлка і ґанок",
+		"αβγ (1970)",
+		"H₂O x²",
+		"שלום",
+		"كتاب",
+		"Sefer Moreh be-ʼeṣbaʻ /",
+	}
+	e := NewMARC8Encoder()
+	for _, c := range cases {
+		enc, lossy := e.Encode(c)
+		if lossy {
+			t.Errorf("%q: got lossy encoding %q", c, enc)
+		}
+		dec, err := DecodeMARC8([]byte(enc))
+		if err != nil {
+			t.Errorf("%q: %v", c, err)
+		}
+		if want := string(e.decompose(c)); dec != want {
+			t.Errorf("%q: got %q, want %q", c, dec, want)
+		}
+	}
+}
+
+func TestMARC8Encode(t *testing.T) {
+	var cases = []struct {
+		in  string
+		out string
+	}{
+		{"é", "\xe2e"},
+		{"Тихий", "\x1b(NtIHIJ\x1b(B"},
+		{"ё.", "\x1b)Q\xc4.\x1b)E"},
+		{"H₂O", "H\x1bb2\x1b(BO"},
+	}
+	e := NewMARC8Encoder()
+	for _, c := range cases {
+		out, _ := e.Encode(c.in)
+		if out != c.out {
+			t.Errorf("%q: got %q, want %q", c.in, out, c.out)
+		}
+	}
+}
+
+func TestMARC8EncodeSubstitute(t *testing.T) {
+	e := NewMARC8Encoder()
+	out, lossy := e.Encode("a☃b")
+	if !lossy || out != "a&#x2603;b" {
+		t.Errorf("got %q, %v, want %q, true", out, lossy, "a&#x2603;b")
+	}
+	e.Substitute = "?"
+	out, lossy = e.Encode("a☃b")
+	if !lossy || out != "a?b" {
+		t.Errorf("got %q, %v, want %q, true", out, lossy, "a?b")
+	}
+}
+
+func TestWriterMARC8(t *testing.T) {
+	record := &Record{Leader: defaultLeader()}
+	record.AddField(&ControlField{Tag: "001", Data: "1"})
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "Céleste ☃"},
+	}})
+	record.AddField(&DataField{Tag: "260", Ind1: ' ', Ind2: ' ', SubFields: []*SubField{
+		{Code: 'a', Value: "Москва"},
+	}})
+
+	var buf bytes.Buffer
+	var lossy []Field
+	w := NewWriter(&buf)
+	w.MARC8 = NewMARC8Encoder()
+	w.MARC8.Substitute = "?"
+	w.OnLossy = func(r *Record, fields []Field) {
+		lossy = fields
+	}
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if len(lossy) != 1 || lossy[0].GetTag() != "245" {
+		t.Errorf("lossy, got %v, want 245", lossy)
+	}
+	if record.Leader.CharacterEncoding != 'a' {
+		t.Errorf("record was modified")
+	}
+
+	r := NewReader(&buf)
+	r.ConvertMARC8 = true
+	parsed, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := parsed.GetSubFields("245", 'a')[0].Value; v != "Céleste ?" {
+		t.Errorf("245, got %q, want %q", v, "Céleste ?")
+	}
+	if v := parsed.GetSubFields("260", 'a')[0].Value; v != "Москва" {
+		t.Errorf("260, got %q, want %q", v, "Москва")
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	record := &Record{Leader: defaultLeader()}
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "é"},
+	}})
+	lossy := NewMARC8Encoder().EncodeRecord(record)
+	if len(lossy) != 0 {
+		t.Errorf("lossy, got %v, want none", lossy)
+	}
+	if v := record.GetSubFields("245", 'a')[0].Value; v != "\xe2e" {
+		t.Errorf("value, got %q, want %q", v, "\xe2e")
+	}
+	if record.Leader.CharacterEncoding != ' ' {
+		t.Errorf("CharacterEncoding, got %q, want %q", record.Leader.CharacterEncoding, ' ')
+	}
+}
@@ -0,0 +1,269 @@
+package marc21
+
+// MARC-8 character sets, following the LC code tables at
+// http://www.loc.gov/marc/specifications/specchartables.html. Codes are
+// given as they appear in the tables; single byte codes are stored with the
+// high bit cleared, so a set can be designated as G0 or G1.
+//
+// The East Asian ideographs (EACC) and the extended Arabic set are too large
+// to be maintained here. They are generated from the LC codetables.xml file
+// into marc8_tables_gen.go, which registers them on init; without it, only
+// their designations are known, their characters are reported as missing
+// from the code table, and the tables can be loaded at run time with
+// LoadMARC8Tables.
+
+//go:generate go run gen_marc8tables.go
+
+// Final characters of the escape sequences designating a character set.
+const (
+	setBasicLatin    = 'B'
+	setExtendedLatin = 'E'
+	setEACC          = '1'
+	setBasicHebrew   = '2'
+	setBasicArabic   = '3'
+	setExtArabic     = '4'
+	setBasicCyrillic = 'N'
+	setExtCyrillic   = 'Q'
+	setBasicGreek    = 'S'
+	setGreekSymbols  = 'g'
+	setSubscripts    = 'b'
+	setSuperscripts  = 'p'
+)
+
+// charEntry is a single character of a MARC-8 character set.
+type charEntry struct {
+	code      int
+	r         rune
+	combining bool
+}
+
+// asciiRange returns identity mappings for the ASCII characters in the range.
+func asciiRange(from, to int) (entries []charEntry) {
+	for c := from; c <= to; c++ {
+		entries = append(entries, charEntry{c, rune(c), false})
+	}
+	return
+}
+
+var basicLatinEntries = asciiRange(0x21, 0x7e)
+
+var extendedLatinEntries = []charEntry{
+	{0xa1, 0x0141, false}, // LATIN CAPITAL LETTER L WITH STROKE
+	{0xa2, 0x00d8, false}, // LATIN CAPITAL LETTER O WITH STROKE
+	{0xa3, 0x0110, false}, // LATIN CAPITAL LETTER D WITH STROKE
+	{0xa4, 0x00de, false}, // LATIN CAPITAL LETTER THORN
+	{0xa5, 0x00c6, false}, // LATIN CAPITAL LETTER AE
+	{0xa6, 0x0152, false}, // LATIN CAPITAL LIGATURE OE
+	{0xa7, 0x02b9, false}, // MODIFIER LETTER PRIME
+	{0xa8, 0x00b7, false}, // MIDDLE DOT
+	{0xa9, 0x266d, false}, // MUSIC FLAT SIGN
+	{0xaa, 0x00ae, false}, // REGISTERED SIGN
+	{0xab, 0x00b1, false}, // PLUS-MINUS SIGN
+	{0xac, 0x01a0, false}, // LATIN CAPITAL LETTER O WITH HORN
+	{0xad, 0x01af, false}, // LATIN CAPITAL LETTER U WITH HORN
+	{0xae, 0x02bc, false}, // MODIFIER LETTER APOSTROPHE
+	{0xb0, 0x02bb, false}, // MODIFIER LETTER TURNED COMMA
+	{0xb1, 0x0142, false}, // LATIN SMALL LETTER L WITH STROKE
+	{0xb2, 0x00f8, false}, // LATIN SMALL LETTER O WITH STROKE
+	{0xb3, 0x0111, false}, // LATIN SMALL LETTER D WITH STROKE
+	{0xb4, 0x00fe, false}, // LATIN SMALL LETTER THORN
+	{0xb5, 0x00e6, false}, // LATIN SMALL LETTER AE
+	{0xb6, 0x0153, false}, // LATIN SMALL LIGATURE OE
+	{0xb7, 0x02ba, false}, // MODIFIER LETTER DOUBLE PRIME
+	{0xb8, 0x0131, false}, // LATIN SMALL LETTER DOTLESS I
+	{0xb9, 0x00a3, false}, // POUND SIGN
+	{0xba, 0x00f0, false}, // LATIN SMALL LETTER ETH
+	{0xbc, 0x01a1, false}, // LATIN SMALL LETTER O WITH HORN
+	{0xbd, 0x01b0, false}, // LATIN SMALL LETTER U WITH HORN
+	{0xc0, 0x00b0, false}, // DEGREE SIGN
+	{0xc1, 0x2113, false}, // SCRIPT SMALL L
+	{0xc2, 0x2117, false}, // SOUND RECORDING COPYRIGHT
+	{0xc3, 0x00a9, false}, // COPYRIGHT SIGN
+	{0xc4, 0x266f, false}, // MUSIC SHARP SIGN
+	{0xc5, 0x00bf, false}, // INVERTED QUESTION MARK
+	{0xc6, 0x00a1, false}, // INVERTED EXCLAMATION MARK
+	{0xc7, 0x00df, false}, // LATIN SMALL LETTER SHARP S
+	{0xc8, 0x20ac, false}, // EURO SIGN
+	{0xe0, 0x0309, true},  // COMBINING HOOK ABOVE
+	{0xe1, 0x0300, true},  // COMBINING GRAVE ACCENT
+	{0xe2, 0x0301, true},  // COMBINING ACUTE ACCENT
+	{0xe3, 0x0302, true},  // COMBINING CIRCUMFLEX ACCENT
+	{0xe4, 0x0303, true},  // COMBINING TILDE
+	{0xe5, 0x0304, true},  // COMBINING MACRON
+	{0xe6, 0x0306, true},  // COMBINING BREVE
+	{0xe7, 0x0307, true},  // COMBINING DOT ABOVE
+	{0xe8, 0x0308, true},  // COMBINING DIAERESIS
+	{0xe9, 0x030c, true},  // COMBINING CARON
+	{0xea, 0x030a, true},  // COMBINING RING ABOVE
+	{0xeb, 0xfe20, true},  // COMBINING LIGATURE LEFT HALF
+	{0xec, 0xfe21, true},  // COMBINING LIGATURE RIGHT HALF
+	{0xed, 0x0315, true},  // COMBINING COMMA ABOVE RIGHT
+	{0xee, 0x030b, true},  // COMBINING DOUBLE ACUTE ACCENT
+	{0xef, 0x0310, true},  // COMBINING CANDRABINDU
+	{0xf0, 0x0327, true},  // COMBINING CEDILLA
+	{0xf1, 0x0328, true},  // COMBINING OGONEK
+	{0xf2, 0x0323, true},  // COMBINING DOT BELOW
+	{0xf3, 0x0324, true},  // COMBINING DIAERESIS BELOW
+	{0xf4, 0x0325, true},  // COMBINING RING BELOW
+	{0xf5, 0x0333, true},  // COMBINING DOUBLE LOW LINE
+	{0xf6, 0x0332, true},  // COMBINING LOW LINE
+	{0xf7, 0x0326, true},  // COMBINING COMMA BELOW
+	{0xf8, 0x031c, true},  // COMBINING LEFT HALF RING BELOW
+	{0xf9, 0x032e, true},  // COMBINING BREVE BELOW
+	{0xfa, 0xfe22, true},  // COMBINING DOUBLE TILDE LEFT HALF
+	{0xfb, 0xfe23, true},  // COMBINING DOUBLE TILDE RIGHT HALF
+	{0xfe, 0x0313, true},  // COMBINING COMMA ABOVE
+}
+
+var basicHebrewEntries = append(asciiRange(0x21, 0x3f), []charEntry{
+	{0x40, 0x05b7, true}, // HEBREW POINT PATAH
+	{0x41, 0x05b8, true}, // HEBREW POINT QAMATS
+	{0x42, 0x05b6, true}, // HEBREW POINT SEGOL
+	{0x43, 0x05b5, true}, // HEBREW POINT TSERE
+	{0x44, 0x05b4, true}, // HEBREW POINT HIRIQ
+	{0x45, 0x05b9, true}, // HEBREW POINT HOLAM
+	{0x46, 0x05bb, true}, // HEBREW POINT QUBUTS
+	{0x47, 0x05b0, true}, // HEBREW POINT SHEVA
+	{0x48, 0x05b2, true}, // HEBREW POINT HATAF PATAH
+	{0x49, 0x05b3, true}, // HEBREW POINT HATAF QAMATS
+	{0x4a, 0x05b1, true}, // HEBREW POINT HATAF SEGOL
+	{0x4b, 0x05bc, true}, // HEBREW POINT DAGESH OR MAPIQ
+	{0x4c, 0x05bf, true}, // HEBREW POINT RAFE
+	{0x4d, 0x05c1, true}, // HEBREW POINT SHIN DOT
+	{0x4e, 0xfb1e, true}, // HEBREW POINT JUDEO-SPANISH VARIKA
+	{0x60, 0x05d0, false}, {0x61, 0x05d1, false}, {0x62, 0x05d2, false},
+	{0x63, 0x05d3, false}, {0x64, 0x05d4, false}, {0x65, 0x05d5, false},
+	{0x66, 0x05d6, false}, {0x67, 0x05d7, false}, {0x68, 0x05d8, false},
+	{0x69, 0x05d9, false}, {0x6a, 0x05da, false}, {0x6b, 0x05db, false},
+	{0x6c, 0x05dc, false}, {0x6d, 0x05dd, false}, {0x6e, 0x05de, false},
+	{0x6f, 0x05df, false}, {0x70, 0x05e0, false}, {0x71, 0x05e1, false},
+	{0x72, 0x05e2, false}, {0x73, 0x05e3, false}, {0x74, 0x05e4, false},
+	{0x75, 0x05e5, false}, {0x76, 0x05e6, false}, {0x77, 0x05e7, false},
+	{0x78, 0x05e8, false}, {0x79, 0x05e9, false}, {0x7a, 0x05ea, false},
+	{0x7b, 0x05f0, false}, // HEBREW LIGATURE YIDDISH DOUBLE VAV
+	{0x7c, 0x05f1, false}, // HEBREW LIGATURE YIDDISH VAV YOD
+	{0x7d, 0x05f2, false}, // HEBREW LIGATURE YIDDISH DOUBLE YOD
+}...)
+
+var basicArabicEntries = []charEntry{
+	{0x21, 0x0021, false}, {0x22, 0x0022, false}, {0x23, 0x0023, false},
+	{0x24, 0x0024, false}, {0x25, 0x066a, false}, {0x26, 0x0026, false},
+	{0x27, 0x0027, false}, {0x28, 0x0028, false}, {0x29, 0x0029, false},
+	{0x2a, 0x066d, false}, {0x2b, 0x002b, false}, {0x2c, 0x060c, false},
+	{0x2d, 0x002d, false}, {0x2e, 0x002e, false}, {0x2f, 0x002f, false},
+	{0x30, 0x0660, false}, {0x31, 0x0661, false}, {0x32, 0x0662, false},
+	{0x33, 0x0663, false}, {0x34, 0x0664, false}, {0x35, 0x0665, false},
+	{0x36, 0x0666, false}, {0x37, 0x0667, false}, {0x38, 0x0668, false},
+	{0x39, 0x0669, false}, {0x3a, 0x003a, false}, {0x3b, 0x061b, false},
+	{0x3c, 0x003c, false}, {0x3d, 0x003d, false}, {0x3e, 0x003e, false},
+	{0x3f, 0x061f, false},
+	{0x41, 0x0621, false}, {0x42, 0x0622, false}, {0x43, 0x0623, false},
+	{0x44, 0x0624, false}, {0x45, 0x0625, false}, {0x46, 0x0626, false},
+	{0x47, 0x0627, false}, {0x48, 0x0628, false}, {0x49, 0x0629, false},
+	{0x4a, 0x062a, false}, {0x4b, 0x062b, false}, {0x4c, 0x062c, false},
+	{0x4d, 0x062d, false}, {0x4e, 0x062e, false}, {0x4f, 0x062f, false},
+	{0x50, 0x0630, false}, {0x51, 0x0631, false}, {0x52, 0x0632, false},
+	{0x53, 0x0633, false}, {0x54, 0x0634, false}, {0x55, 0x0635, false},
+	{0x56, 0x0636, false}, {0x57, 0x0637, false}, {0x58, 0x0638, false},
+	{0x59, 0x0639, false}, {0x5a, 0x063a, false},
+	{0x60, 0x0640, false}, {0x61, 0x0641, false}, {0x62, 0x0642, false},
+	{0x63, 0x0643, false}, {0x64, 0x0644, false}, {0x65, 0x0645, false},
+	{0x66, 0x0646, false}, {0x67, 0x0647, false}, {0x68, 0x0648, false},
+	{0x69, 0x0649, false}, {0x6a, 0x064a, false},
+	{0x6b, 0x064b, true}, // ARABIC FATHATAN
+	{0x6c, 0x064c, true}, // ARABIC DAMMATAN
+	{0x6d, 0x064d, true}, // ARABIC KASRATAN
+	{0x6e, 0x064e, true}, // ARABIC FATHA
+	{0x6f, 0x064f, true}, // ARABIC DAMMA
+	{0x70, 0x0650, true}, // ARABIC KASRA
+	{0x71, 0x0651, true}, // ARABIC SHADDA
+	{0x72, 0x0652, true}, // ARABIC SUKUN
+	{0x73, 0x0671, false},
+	{0x74, 0x0670, true}, // ARABIC LETTER SUPERSCRIPT ALEF
+}
+
+// basicCyrillicEntries follows the layout of KOI-7: lowercase letters at
+// 0x40-0x5f, uppercase letters at 0x60-0x7e.
+var basicCyrillicEntries = func() []charEntry {
+	entries := asciiRange(0x21, 0x3f)
+	letters := []rune("юабцдефгхийклмнопярстужвьызшэщчъ")
+	for i, r := range letters {
+		entries = append(entries, charEntry{0x40 + i, r, false})
+	}
+	upper := []rune("ЮАБЦДЕФГХИЙКЛМНОПЯРСТУЖВЬЫЗШЭЩЧ")
+	for i, r := range upper {
+		entries = append(entries, charEntry{0x60 + i, r, false})
+	}
+	return entries
+}()
+
+var extendedCyrillicEntries = []charEntry{
+	{0xc0, 0x0491, false}, {0xc1, 0x0452, false}, {0xc2, 0x0453, false},
+	{0xc3, 0x0454, false}, {0xc4, 0x0451, false}, {0xc5, 0x0455, false},
+	{0xc6, 0x0456, false}, {0xc7, 0x0457, false}, {0xc8, 0x0458, false},
+	{0xc9, 0x0459, false}, {0xca, 0x045a, false}, {0xcb, 0x045b, false},
+	{0xcc, 0x045c, false}, {0xcd, 0x045e, false}, {0xce, 0x045f, false},
+	{0xd0, 0x0463, false}, {0xd1, 0x0473, false}, {0xd2, 0x0475, false},
+	{0xd3, 0x046b, false},
+	{0xe0, 0x0490, false}, {0xe1, 0x0402, false}, {0xe2, 0x0403, false},
+	{0xe3, 0x0404, false}, {0xe4, 0x0401, false}, {0xe5, 0x0405, false},
+	{0xe6, 0x0406, false}, {0xe7, 0x0407, false}, {0xe8, 0x0408, false},
+	{0xe9, 0x0409, false}, {0xea, 0x040a, false}, {0xeb, 0x040b, false},
+	{0xec, 0x040c, false}, {0xed, 0x040e, false}, {0xee, 0x040f, false},
+	{0xef, 0x042a, false},
+	{0xf0, 0x0462, false}, {0xf1, 0x0472, false}, {0xf2, 0x0474, false},
+	{0xf3, 0x046a, false},
+}
+
+var basicGreekEntries = []charEntry{
+	{0x21, 0x0300, true}, // COMBINING GRAVE ACCENT
+	{0x22, 0x0301, true}, // COMBINING ACUTE ACCENT
+	{0x23, 0x0308, true}, // COMBINING DIAERESIS
+	{0x24, 0x0342, true}, // COMBINING GREEK PERISPOMENI
+	{0x25, 0x0313, true}, // COMBINING COMMA ABOVE
+	{0x26, 0x0314, true}, // COMBINING REVERSED COMMA ABOVE
+	{0x27, 0x0345, true}, // COMBINING GREEK YPOGEGRAMMENI
+	{0x30, 0x00ab, false}, {0x31, 0x00bb, false}, {0x32, 0x201c, false},
+	{0x33, 0x201d, false}, {0x34, 0x0374, false}, {0x35, 0x0375, false},
+	{0x3b, 0x0387, false}, {0x3f, 0x037e, false},
+	{0x41, 0x0391, false}, {0x42, 0x0392, false}, {0x44, 0x0393, false},
+	{0x45, 0x0394, false}, {0x46, 0x0395, false}, {0x47, 0x03da, false},
+	{0x48, 0x03dc, false}, {0x49, 0x0396, false}, {0x4a, 0x0397, false},
+	{0x4b, 0x0398, false}, {0x4c, 0x0399, false}, {0x4d, 0x039a, false},
+	{0x4e, 0x039b, false}, {0x4f, 0x039c, false}, {0x50, 0x039d, false},
+	{0x51, 0x039e, false}, {0x52, 0x039f, false}, {0x53, 0x03a0, false},
+	{0x54, 0x03de, false}, {0x55, 0x03a1, false}, {0x56, 0x03a3, false},
+	{0x58, 0x03a4, false}, {0x59, 0x03a5, false}, {0x5a, 0x03a6, false},
+	{0x5b, 0x03a7, false}, {0x5c, 0x03a8, false}, {0x5d, 0x03a9, false},
+	{0x5e, 0x03e0, false},
+	{0x61, 0x03b1, false}, {0x62, 0x03b2, false}, {0x63, 0x03d0, false},
+	{0x64, 0x03b3, false}, {0x65, 0x03b4, false}, {0x66, 0x03b5, false},
+	{0x67, 0x03db, false}, {0x68, 0x03dd, false}, {0x69, 0x03b6, false},
+	{0x6a, 0x03b7, false}, {0x6b, 0x03b8, false}, {0x6c, 0x03b9, false},
+	{0x6d, 0x03ba, false}, {0x6e, 0x03bb, false}, {0x6f, 0x03bc, false},
+	{0x70, 0x03bd, false}, {0x71, 0x03be, false}, {0x72, 0x03bf, false},
+	{0x73, 0x03c0, false}, {0x74, 0x03df, false}, {0x75, 0x03c1, false},
+	{0x76, 0x03c3, false}, {0x77, 0x03c2, false}, {0x78, 0x03c4, false},
+	{0x79, 0x03c5, false}, {0x7a, 0x03c6, false}, {0x7b, 0x03c7, false},
+	{0x7c, 0x03c8, false}, {0x7d, 0x03c9, false}, {0x7e, 0x03e1, false},
+}
+
+var greekSymbolEntries = []charEntry{
+	{0x61, 0x03b1, false}, {0x62, 0x03b2, false}, {0x63, 0x03b3, false},
+}
+
+var subscriptEntries = []charEntry{
+	{0x28, 0x208d, false}, {0x29, 0x208e, false}, {0x2b, 0x208a, false},
+	{0x2d, 0x208b, false}, {0x30, 0x2080, false}, {0x31, 0x2081, false},
+	{0x32, 0x2082, false}, {0x33, 0x2083, false}, {0x34, 0x2084, false},
+	{0x35, 0x2085, false}, {0x36, 0x2086, false}, {0x37, 0x2087, false},
+	{0x38, 0x2088, false}, {0x39, 0x2089, false},
+}
+
+var superscriptEntries = []charEntry{
+	{0x28, 0x207d, false}, {0x29, 0x207e, false}, {0x2b, 0x207a, false},
+	{0x2d, 0x207b, false}, {0x30, 0x2070, false}, {0x31, 0x00b9, false},
+	{0x32, 0x00b2, false}, {0x33, 0x00b3, false}, {0x34, 0x2074, false},
+	{0x35, 0x2075, false}, {0x36, 0x2076, false}, {0x37, 0x2077, false},
+	{0x38, 0x2078, false}, {0x39, 0x2079, false},
+}
@@ -0,0 +1,179 @@
+package marc21
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMARC8(t *testing.T) {
+	var cases = []struct {
+		about string
+		in    string
+		out   string
+	}{
+		{"ascii", "Arithmetic /", "Arithmetic /"},
+		{"combining", "C\xe2eleste", "Ce\u0301leste"},
+		{"two combining", "\xe3\xf2a", "a\u0302\u0323"},
+		{"ligature", "\xebi\xecadernogo", "i︠a︡dernogo"},
+		{"extended latin", "\xb1\xc3d\xb2", "ł©dø"},
+		{"cyrillic", "\x1b(NAB\x1b(B.", "аб."},
+		{"uppercase cyrillic", "\x1b(N`a\x1b(B", "ЮА"},
+		{"extended cyrillic g1", "\x1b)Q\xc4\x1b)E", "ё"},
+		{"greek", "\x1b(Sabd\x1b(B", "αβγ"},
+		{"greek symbols", "\x1bgb\x1bs", "β"},
+		{"subscript", "H\x1bb2\x1bsO", "H₂O"},
+		{"superscript", "x\x1bp2\x1bs", "x²"},
+		{"hebrew", "\x1b(2`a\x1b(B", "אב"},
+		{"arabic", "\x1b(3GH\x1b(B", "اب"},
+		{"space in other set", "\x1b(NA A\x1b(B", "а а"},
+		{"non-sort", "\x88The \x89book", "\u0098The \u009cbook"},
+	}
+	for _, c := range cases {
+		out, err := DecodeMARC8([]byte(c.in))
+		if err != nil {
+			t.Errorf("%s: %v", c.about, err)
+		}
+		if out != c.out {
+			t.Errorf("%s: got %q, want %q", c.about, out, c.out)
+		}
+	}
+}
+
+func TestDecodeMARC8Errors(t *testing.T) {
+	for _, in := range []string{"a\xafb", "\x1b(Zx", "\x1b$1!0", "\x1b"} {
+		out, err := DecodeMARC8([]byte(in))
+		if err == nil {
+			t.Errorf("%q: got nil, want some error", in)
+		}
+		if !strings.ContainsRune(out, '�') {
+			t.Errorf("%q: got %q, want replacement character", in, out)
+		}
+	}
+}
+
+func TestLoadMARC8Tables(t *testing.T) {
+	const tables = `<?xml version="1.0"?>
+<codeTables>
+<codeTable name="East Asian Ideographs">
+<characterSet name="East Asian Ideographs" ISOcode="31">
+<code><marc>213021</marc><ucs>4E00</ucs><utf-8>E4B880</utf-8><name>one</name></code>
+</characterSet>
+</codeTable>
+</codeTables>`
+	saved := charsets[setEACC]
+	defer func() { charsets[setEACC] = saved }()
+	charsets[setEACC] = newCharset(setEACC, true, nil)
+
+	if err := LoadMARC8Tables(strings.NewReader(tables)); err != nil {
+		t.Fatal(err)
+	}
+	out, err := DecodeMARC8([]byte("\x1b$1!0!\x1b(B."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "一." {
+		t.Errorf("got %q, want %q", out, "一.")
+	}
+}
+
+func TestDecodeMARC8EACC(t *testing.T) {
+	out, err := DecodeMARC8([]byte("\x1b$1!0!\x1b(B abc"))
+	if len(charsets[setEACC].chars) == 0 {
+		// Without generated tables, the missing table must be reported.
+		want := "no code table for MARC-8 character set '1', see LoadMARC8Tables"
+		if err == nil || err.Error() != want {
+			t.Errorf("got %v, want %v", err, want)
+		}
+		if out != "\ufffd abc" {
+			t.Errorf("got %q, want %q", out, "\ufffd abc")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "一 abc" {
+		t.Errorf("got %q, want %q", out, "一 abc")
+	}
+}
+
+func TestRecordDecodeMARC8(t *testing.T) {
+	record := &Record{Leader: defaultLeader()}
+	record.Leader.CharacterEncoding = ' '
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "C\xe2eleste dragon"},
+	}})
+	if err := record.DecodeMARC8(); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetSubFields("245", 'a')[0].Value; v != "Ce\u0301leste dragon" {
+		t.Errorf("value, got %q, want %q", v, "Ce\u0301leste dragon")
+	}
+	if record.Leader.CharacterEncoding != 'a' {
+		t.Errorf("CharacterEncoding, got %q, want %q", record.Leader.CharacterEncoding, 'a')
+	}
+}
+
+func TestReaderConvertMARC8(t *testing.T) {
+	file := openTestMARC(t)
+	defer file.Close()
+
+	r := NewReader(file)
+	r.ConvertMARC8 = true
+	for r.Scan() {
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if r.Count() != 85 {
+		t.Errorf("Count, got %v, want %v", r.Count(), 85)
+	}
+}
+
+func TestReaderMARC8Error(t *testing.T) {
+	b := buildRecord("001000200000245000800002", "1\x1e10\x1faa\xafb\x1e")
+	b = append(b, b...)
+	b[9] = ' '
+	b[len(b)/2+9] = ' '
+	want := "a\ufffdb"
+
+	var errs []string
+	onError := func(record *Record, err error) {
+		errs = append(errs, record.Identifier()+": "+err.Error())
+	}
+	r := NewReader(bytes.NewReader(b))
+	r.ConvertMARC8 = true
+	r.OnMARC8Error = onError
+	for r.Scan() {
+		if v := r.Record().GetSubFields("245", 'a')[0].Value; v != want {
+			t.Errorf("Reader, got %q, want %q", v, want)
+		}
+	}
+	if r.Err() != nil || r.Count() != 2 {
+		t.Errorf("Reader, got %v records and error %v, want 2 records", r.Count(), r.Err())
+	}
+	if len(errs) != 2 {
+		t.Errorf("Reader, got %q, want two conversion errors", errs)
+	}
+
+	errs = nil
+	p := NewPipeline(bytes.NewReader(b))
+	p.ConvertMARC8 = true
+	p.OnMARC8Error = onError
+	var n int
+	err := p.Each(context.Background(), func(record *Record) error {
+		n++
+		if len(errs) != n {
+			t.Errorf("Pipeline, got %d conversion errors before record %d", len(errs), n)
+		}
+		if v := record.GetSubFields("245", 'a')[0].Value; v != want {
+			t.Errorf("Pipeline, got %q, want %q", v, want)
+		}
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Errorf("Pipeline, got %v records and error %v, want 2 records", n, err)
+	}
+}
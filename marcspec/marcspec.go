@@ -0,0 +1,419 @@
+// Package marcspec implements MARCspec, a language for referencing data in
+// MARC records, see https://marcspec.github.io/MARCspec/.
+//
+// A spec is compiled once and evaluated against any number of records:
+//
+//	spec := marcspec.MustCompile("650$a{$2=\\fast}")
+//	for _, v := range spec.Values(record) {
+//	    ...
+//	}
+//
+// Supported are:
+//
+//	245           fields by tag, "." matches any character: 6..
+//	LDR           the leader
+//	020[0]        by index, zero based: [1-3], [#] is the last, [1-#]
+//	008/35-37     character positions of control fields, the leader and
+//	              subfields: /0, /#, /7-#
+//	245$a         subfields: $a$c, a range of codes: $a-c, with index and
+//	              character positions: $a[0], $a/0-3
+//	245_10$a      fields with the given indicators, "_" matches any
+//	              indicator, "#" a blank: 245_1_
+//	245^1         an indicator
+//	{...}         conditions, which select fields or subfields
+//
+// A condition compares two terms with = (equal), != (not equal), ~
+// (contains) or !~ (does not contain), or tests the existence of a term with
+// ? or its absence with !, which is also the meaning of a term alone:
+//
+//	650$a{$2=\fast}   subfields a of fields 650 with a subfield 2 "fast"
+//	245{^2=\4}        fields 245 with the second indicator 4
+//	100{!$t}$a        subfields a of fields 100 without subfield t
+//	LDR/06{LDR/07=\m} position 6 of the leader, if position 7 is "m"
+//
+// Terms are specs, comparison strings starting with a backslash or specs
+// without a tag, like $2, ^1 or /0-3, which refer to the current field or,
+// in conditions of subfields, the current subfield. In comparison strings,
+// the characters {}!=~?|\ are escaped with a backslash and \s is a blank.
+// Several conditions in one pair of braces, separated by |, are alternatives;
+// several pairs of braces must all be met.
+//
+// Fields are selected by tag and indicators first, then by index, then by
+// conditions; subfields by code, index and conditions. A condition holds, if
+// any value of one term equals or contains any value of the other; the
+// negated operators hold, if the positive ones do not.
+package marcspec
+
+import (
+	"strings"
+
+	"github.com/miku/marc21"
+)
+
+// leaderTag is the tag, that refers to the leader.
+const leaderTag = "LDR"
+
+// Spec is a compiled MARCspec.
+type Spec struct {
+	source string
+	// relative specs have no tag and refer to the current field or value,
+	// as found in conditions.
+	relative  bool
+	field     fieldSpec
+	indicator int
+	subfields []*subfieldSpec
+}
+
+// fieldSpec selects fields.
+type fieldSpec struct {
+	tag        string
+	ind1, ind2 byte
+	index      *span
+	chars      *span
+	conditions [][]*condition
+}
+
+// subfieldSpec selects subfields within a field.
+type subfieldSpec struct {
+	from, to   byte
+	index      *span
+	chars      *span
+	conditions [][]*condition
+}
+
+// span is an index or a range of character positions, both inclusive. A
+// negative value refers to the last position.
+type span struct {
+	start, end int
+}
+
+// condition compares two terms or tests the existence of one.
+type condition struct {
+	op          string
+	left, right *term
+}
+
+// term is a comparison string or a spec.
+type term struct {
+	literal string
+	spec    *Spec
+}
+
+// context is what relative specs in conditions refer to.
+type context struct {
+	record *marc21.Record
+	field  marc21.Field
+	value  string
+}
+
+// MustCompile is like Compile, but panics, if the spec cannot be parsed.
+func MustCompile(s string) *Spec {
+	spec, err := Compile(s)
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+// String returns the source of the spec.
+func (spec *Spec) String() string {
+	return spec.source
+}
+
+// Values returns the values referenced by the spec in record order. The
+// value of a data field referenced without subfields are its subfield values
+// separated by blanks.
+func (spec *Spec) Values(record *marc21.Record) (values []string) {
+	if spec.field.tag == leaderTag {
+		if record.Leader == nil {
+			return nil
+		}
+		v := record.Leader.String()
+		ctx := context{record: record, value: v}
+		if !holds(spec.field.conditions, ctx) {
+			return nil
+		}
+		if v, ok := spec.field.chars.slice(v); ok {
+			return []string{v}
+		}
+		return nil
+	}
+	for _, f := range spec.field.match(record) {
+		values = append(values, spec.fieldValues(record, f)...)
+	}
+	return values
+}
+
+// Fields returns the fields referenced by the spec. A spec of the leader
+// references no fields.
+func (spec *Spec) Fields(record *marc21.Record) []marc21.Field {
+	if spec.field.tag == leaderTag {
+		return nil
+	}
+	return spec.field.match(record)
+}
+
+// SubFields returns the subfields referenced by the spec, without regard to
+// character positions.
+func (spec *Spec) SubFields(record *marc21.Record) (subfields []*marc21.SubField) {
+	if spec.field.tag == leaderTag || len(spec.subfields) == 0 {
+		return nil
+	}
+	for _, f := range spec.field.match(record) {
+		if df, ok := f.(*marc21.DataField); ok {
+			for _, m := range matchSubFields(record, df, spec.subfields) {
+				subfields = append(subfields, m.sf)
+			}
+		}
+	}
+	return subfields
+}
+
+// fieldValues returns the values of a single field, that has been selected
+// by the field spec.
+func (spec *Spec) fieldValues(record *marc21.Record, f marc21.Field) (values []string) {
+	switch {
+	case spec.indicator > 0:
+		if df, ok := f.(*marc21.DataField); ok {
+			ind := df.Ind1
+			if spec.indicator == 2 {
+				ind = df.Ind2
+			}
+			values = append(values, string(ind))
+		}
+	case len(spec.subfields) > 0:
+		if df, ok := f.(*marc21.DataField); ok {
+			for _, m := range matchSubFields(record, df, spec.subfields) {
+				if v, ok := m.spec.chars.slice(m.sf.Value); ok {
+					values = append(values, v)
+				}
+			}
+		}
+	default:
+		if v, ok := spec.field.chars.slice(fieldValue(f)); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// relativeValues returns the values of a relative spec.
+func (spec *Spec) relativeValues(ctx context) []string {
+	if spec.indicator == 0 && len(spec.subfields) == 0 {
+		if v, ok := spec.field.chars.slice(ctx.value); ok {
+			return []string{v}
+		}
+		return nil
+	}
+	if ctx.field == nil {
+		return nil
+	}
+	return spec.fieldValues(ctx.record, ctx.field)
+}
+
+// fieldValue returns the data of a control field or the subfield values of
+// a data field, separated by blanks.
+func fieldValue(f marc21.Field) string {
+	switch field := f.(type) {
+	case *marc21.ControlField:
+		return field.Data
+	case *marc21.DataField:
+		values := make([]string, len(field.SubFields))
+		for i, sf := range field.SubFields {
+			values[i] = sf.Value
+		}
+		return strings.Join(values, " ")
+	}
+	return ""
+}
+
+// match returns the fields of a record selected by tag, indicators, index
+// and conditions, in this order.
+func (fs *fieldSpec) match(record *marc21.Record) []marc21.Field {
+	var fields []marc21.Field
+	for _, f := range record.Fields {
+		if matchTag(fs.tag, f.GetTag()) && fs.matchIndicators(f) {
+			fields = append(fields, f)
+		}
+	}
+	if fs.index != nil {
+		from, to, ok := fs.index.bounds(len(fields))
+		if !ok {
+			return nil
+		}
+		fields = fields[from : to+1]
+	}
+	if len(fs.conditions) == 0 {
+		return fields
+	}
+	var selected []marc21.Field
+	for _, f := range fields {
+		if holds(fs.conditions, context{record: record, field: f, value: fieldValue(f)}) {
+			selected = append(selected, f)
+		}
+	}
+	return selected
+}
+
+// matchIndicators reports, whether a field has the indicators of the spec.
+// Only data fields have indicators.
+func (fs *fieldSpec) matchIndicators(f marc21.Field) bool {
+	if fs.ind1 == 0 && fs.ind2 == 0 {
+		return true
+	}
+	df, ok := f.(*marc21.DataField)
+	if !ok {
+		return false
+	}
+	return (fs.ind1 == 0 || fs.ind1 == df.Ind1) && (fs.ind2 == 0 || fs.ind2 == df.Ind2)
+}
+
+// matchTag reports, whether a tag matches a pattern, in which "." matches
+// any character.
+func matchTag(pattern, tag string) bool {
+	if len(pattern) != len(tag) {
+		return false
+	}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '.' && pattern[i] != tag[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// subfieldMatch is a subfield and the spec, that selected it.
+type subfieldMatch struct {
+	sf   *marc21.SubField
+	spec *subfieldSpec
+}
+
+// matchSubFields returns the subfields of a field selected by any of the
+// subfield specs, in field order.
+func matchSubFields(record *marc21.Record, df *marc21.DataField, specs []*subfieldSpec) []subfieldMatch {
+	selected := make(map[*marc21.SubField]*subfieldSpec)
+	for _, ss := range specs {
+		for _, sf := range ss.match(record, df) {
+			if _, ok := selected[sf]; !ok {
+				selected[sf] = ss
+			}
+		}
+	}
+	var matches []subfieldMatch
+	for _, sf := range df.SubFields {
+		if ss, ok := selected[sf]; ok {
+			matches = append(matches, subfieldMatch{sf: sf, spec: ss})
+		}
+	}
+	return matches
+}
+
+// match returns the subfields of a field selected by code, index and
+// conditions.
+func (ss *subfieldSpec) match(record *marc21.Record, df *marc21.DataField) []*marc21.SubField {
+	var subfields []*marc21.SubField
+	for _, sf := range df.SubFields {
+		if sf != nil && sf.Code >= ss.from && sf.Code <= ss.to {
+			subfields = append(subfields, sf)
+		}
+	}
+	if ss.index != nil {
+		from, to, ok := ss.index.bounds(len(subfields))
+		if !ok {
+			return nil
+		}
+		subfields = subfields[from : to+1]
+	}
+	if len(ss.conditions) == 0 {
+		return subfields
+	}
+	var selected []*marc21.SubField
+	for _, sf := range subfields {
+		if holds(ss.conditions, context{record: record, field: df, value: sf.Value}) {
+			selected = append(selected, sf)
+		}
+	}
+	return selected
+}
+
+// bounds returns the first and last position of a span within n elements.
+// It returns false, if the span selects no element.
+func (s *span) bounds(n int) (from, to int, ok bool) {
+	from, to = s.start, s.end
+	if from < 0 {
+		from = n - 1
+	}
+	if to < 0 || to >= n {
+		to = n - 1
+	}
+	return from, to, from >= 0 && from <= to
+}
+
+// slice returns the characters of v within the span, or v itself, if the
+// span is nil. It returns false, if the span is beyond the end of v.
+func (s *span) slice(v string) (string, bool) {
+	if s == nil {
+		return v, true
+	}
+	runes := []rune(v)
+	from, to, ok := s.bounds(len(runes))
+	if !ok {
+		return "", false
+	}
+	return string(runes[from : to+1]), true
+}
+
+// holds reports, whether all groups of conditions are met, each by at least
+// one of its conditions.
+func holds(groups [][]*condition, ctx context) bool {
+	for _, group := range groups {
+		ok := false
+		for _, c := range group {
+			if c.holds(ctx) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// holds evaluates a single condition.
+func (c *condition) holds(ctx context) bool {
+	left := c.left.values(ctx)
+	switch c.op {
+	case "?":
+		return len(left) > 0
+	case "!":
+		return len(left) == 0
+	}
+	right := c.right.values(ctx)
+	contains := strings.HasSuffix(c.op, "~")
+	found := false
+	for _, l := range left {
+		for _, r := range right {
+			if (contains && strings.Contains(l, r)) || (!contains && l == r) {
+				found = true
+			}
+		}
+	}
+	if strings.HasPrefix(c.op, "!") {
+		return !found
+	}
+	return found
+}
+
+// values returns the values of a term in a context.
+func (t *term) values(ctx context) []string {
+	switch {
+	case t.spec == nil:
+		return []string{t.literal}
+	case t.spec.relative:
+		return t.spec.relativeValues(ctx)
+	default:
+		return t.spec.Values(ctx.record)
+	}
+}
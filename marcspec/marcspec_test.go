@@ -0,0 +1,154 @@
+package marcspec
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/miku/marc21"
+)
+
+// testRecord returns a small record with repeated fields and subfields.
+func testRecord() *marc21.Record {
+	record := &marc21.Record{Leader: &marc21.Leader{
+		Length: 500, Status: 'n', Type: 'a',
+		ImplementationDefined: [5]byte{'m', ' ', ' ', ' ', ' '},
+		CharacterEncoding:     'a', IndicatorCount: 2, SubfieldCodeLength: 2,
+		BaseAddress: 100, LengthOfLength: 4, LengthOfStartPos: 5,
+	}}
+	record.AddField(&marc21.ControlField{Tag: "001", Data: "12345"})
+	record.AddField(&marc21.ControlField{Tag: "008", Data: "790104s1979    nyu           000 1 eng  "})
+	field := func(tag string, ind1, ind2 byte, sf ...string) {
+		df := &marc21.DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+		for i := 0; i < len(sf); i += 2 {
+			df.AddSubField(sf[i][0], sf[i+1])
+		}
+		record.AddField(df)
+	}
+	field("020", ' ', ' ', "a", "0394502280", "q", "hardcover")
+	field("020", ' ', ' ', "a", "9780394502281")
+	field("100", '1', ' ', "a", "Doctorow, E. L.", "d", "1931-2015")
+	field("245", '1', '0', "a", "Loon Lake /", "c", "E. L. Doctorow.")
+	field("650", ' ', '0', "a", "Depressions", "y", "1929", "z", "United States", "v", "Fiction.")
+	field("650", ' ', '7', "a", "Depressions, 1929", "2", "fast")
+	field("651", ' ', '7', "a", "United States", "2", "fast")
+	return record
+}
+
+func TestValues(t *testing.T) {
+	var cases = []struct {
+		spec string
+		want []string
+	}{
+		{"001", []string{"12345"}},
+		{"245$a", []string{"Loon Lake /"}},
+		{"245$a$c", []string{"Loon Lake /", "E. L. Doctorow."}},
+		{"245$c$a", []string{"Loon Lake /", "E. L. Doctorow."}},
+		{"245", []string{"Loon Lake / E. L. Doctorow."}},
+		{"020$a", []string{"0394502280", "9780394502281"}},
+		{"020[0]$a", []string{"0394502280"}},
+		{"020[#]$a", []string{"9780394502281"}},
+		{"020[0-#]$a", []string{"0394502280", "9780394502281"}},
+		{"020[2]$a", nil},
+		{"008/35-37", []string{"eng"}},
+		{"008/7-10", []string{"1979"}},
+		{"008/#", []string{" "}},
+		{"LDR/06", []string{"a"}},
+		{"LDR/0-4", []string{"00500"}},
+		{"LDR/06{LDR/07=\\m}", []string{"a"}},
+		{"LDR/06{/07=\\s}", nil},
+		{"245_10$a", []string{"Loon Lake /"}},
+		{"245_1_$a", []string{"Loon Lake /"}},
+		{"245_11$a", nil},
+		{"650_#7$a", []string{"Depressions, 1929"}},
+		{"245^1", []string{"1"}},
+		{"245^2", []string{"0"}},
+		{"65.$a", []string{"Depressions", "Depressions, 1929", "United States"}},
+		{"65.$a{$2=\\fast}", []string{"Depressions, 1929", "United States"}},
+		{"65.{$2=\\fast}$a", []string{"Depressions, 1929", "United States"}},
+		{"650$a{$2!=\\fast}", []string{"Depressions"}},
+		{"650{^2=\\0}$z", []string{"United States"}},
+		{"650$a{$2}", []string{"Depressions, 1929"}},
+		{"650$a{!$2}", []string{"Depressions"}},
+		{"650$a{?$y}", []string{"Depressions"}},
+		{"650$a{$a~\\,}", []string{"Depressions, 1929"}},
+		{"650$a{/0-1=\\De}", []string{"Depressions", "Depressions, 1929"}},
+		{"650$a{$a!~\\1929}", []string{"Depressions"}},
+		{"650$v-z", []string{"1929", "United States", "Fiction."}},
+		{"650[0]$a-z[1]", []string{"1929"}},
+		{"100$a/0-7", []string{"Doctorow"}},
+		{"100$a/#", []string{"."}},
+		{"100$a{245$c~\\Doctorow}", []string{"Doctorow, E. L."}},
+		{"100$a{245$c~\\Morrison}", nil},
+		{"100$a{$d=\\1931-2015|$d=\\1931-}", []string{"Doctorow, E. L."}},
+		{"100$a{$d=\\1931-}{$d=\\1931-2015}", nil},
+		{"651$a{$a=650[0]$z}", []string{"United States"}},
+		{"020$q{\\hardcover=$q}", []string{"hardcover"}},
+		{"999$a", nil},
+	}
+	record := testRecord()
+	for _, c := range cases {
+		spec, err := Compile(c.spec)
+		if err != nil {
+			t.Errorf("%s: %s", c.spec, err)
+			continue
+		}
+		if got := spec.Values(record); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s, got %q, want %q", c.spec, got, c.want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	record := testRecord()
+	fields := MustCompile("65.{$2=\\fast}").Fields(record)
+	if len(fields) != 2 || fields[0] != record.Fields[7] || fields[1] != record.Fields[8] {
+		t.Errorf("Fields, got %v, want the fields 650 and 651 with $2 fast", fields)
+	}
+	subfields := MustCompile("020$a").SubFields(record)
+	if len(subfields) != 2 || subfields[1] != record.Fields[3].(*marc21.DataField).SubFields[0] {
+		t.Errorf("SubFields, got %v, want both 020$a", subfields)
+	}
+	subfields[0].Value = "X"
+	if v := record.GetSubFields("020", 'a')[0].Value; v != "X" {
+		t.Errorf("SubFields, got a copy, want a reference")
+	}
+	if fields := MustCompile("LDR").Fields(record); fields != nil {
+		t.Errorf("LDR, got %v, want no fields", fields)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, s := range []string{
+		"", "24", "2#5", "245[", "245[a]", "245[3-1]", "245$", "245$a{", "245$a{$b",
+		"245/1-", "245^3", "245_", "LDR$a", "LDR[0]", "245$a/", "245/0$a", "245$c-a",
+		"245$a{}", "245 $a",
+	} {
+		if _, err := Compile(s); err == nil {
+			t.Errorf("%q: got nil error", s)
+		}
+	}
+}
+
+func TestValuesFixture(t *testing.T) {
+	file, err := os.Open("../fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	spec := MustCompile("245$a")
+	r := marc21.NewReader(file)
+	for r.Scan() {
+		record := r.Record()
+		var want []string
+		for _, sf := range record.GetSubFields("245", 'a') {
+			want = append(want, sf.Value)
+		}
+		if got := spec.Values(record); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s, got %q, want %q", record.Identifier(), got, want)
+		}
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+}
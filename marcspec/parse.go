@@ -0,0 +1,307 @@
+package marcspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compile parses a MARCspec.
+func Compile(s string) (*Spec, error) {
+	p := &parser{s: s}
+	spec, err := p.spec(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(s) {
+		return nil, p.errorf("unexpected %q", s[p.pos])
+	}
+	spec.source = s
+	return spec, nil
+}
+
+// parser is a recursive descent parser for MARCspecs.
+type parser struct {
+	s   string
+	pos int
+}
+
+// errorf returns an error at the current position.
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("marcspec: invalid spec %q at position %d: %s", p.s, p.pos, fmt.Sprintf(format, a...))
+}
+
+// peek reports, whether the next character is c.
+func (p *parser) peek(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+// accept consumes the next character, if it is c.
+func (p *parser) accept(c byte) bool {
+	if p.peek(c) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect consumes the next character, which must be c.
+func (p *parser) expect(c byte) error {
+	if !p.accept(c) {
+		return p.errorf("expected %q", c)
+	}
+	return nil
+}
+
+// spec parses a field, subfield or indicator spec. Relative specs, as used
+// in conditions, have no tag.
+func (p *parser) spec(relative bool) (spec *Spec, err error) {
+	spec = &Spec{relative: relative}
+	fs := &spec.field
+	if relative {
+		if p.accept('/') {
+			fs.chars, err = p.span()
+			return spec, err
+		}
+	} else {
+		if err = p.tag(fs); err != nil {
+			return nil, err
+		}
+		if fs.index, err = p.index(); err != nil {
+			return nil, err
+		}
+		if p.accept('_') {
+			if err = p.indicators(fs); err != nil {
+				return nil, err
+			}
+		}
+		if p.accept('/') {
+			if fs.chars, err = p.span(); err != nil {
+				return nil, err
+			}
+		}
+		if err = p.conditionsOf(&fs.conditions); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept('^') {
+		switch {
+		case p.accept('1'):
+			spec.indicator = 1
+		case p.accept('2'):
+			spec.indicator = 2
+		default:
+			return nil, p.errorf("expected indicator 1 or 2")
+		}
+		if err = p.conditionsOf(&fs.conditions); err != nil {
+			return nil, err
+		}
+	}
+	for spec.indicator == 0 && p.accept('$') {
+		ss, err := p.subfield()
+		if err != nil {
+			return nil, err
+		}
+		spec.subfields = append(spec.subfields, ss)
+	}
+	switch {
+	case relative && spec.indicator == 0 && len(spec.subfields) == 0:
+		return nil, p.errorf("expected a subfield, indicator or character spec")
+	case fs.chars != nil && (spec.indicator > 0 || len(spec.subfields) > 0):
+		return nil, p.errorf("character positions of a field with subfields or indicators")
+	case fs.tag == leaderTag && (fs.index != nil || fs.ind1 != 0 || fs.ind2 != 0 ||
+		spec.indicator > 0 || len(spec.subfields) > 0):
+		return nil, p.errorf("the leader has no index, indicators or subfields")
+	}
+	return spec, nil
+}
+
+// tag parses a field tag of three letters, digits or dots.
+func (p *parser) tag(fs *fieldSpec) error {
+	if len(p.s)-p.pos < 3 {
+		return p.errorf("expected a tag")
+	}
+	tag := p.s[p.pos : p.pos+3]
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '.') {
+			return p.errorf("invalid tag %q", tag)
+		}
+	}
+	fs.tag = tag
+	p.pos += 3
+	return nil
+}
+
+// indicators parses one or two indicators following "_", where "_" is any
+// indicator and "#" a blank.
+func (p *parser) indicators(fs *fieldSpec) error {
+	for i, ind := range []*byte{&fs.ind1, &fs.ind2} {
+		if p.pos == len(p.s) || strings.IndexByte("$^{/[", p.s[p.pos]) >= 0 {
+			if i == 0 {
+				return p.errorf("expected an indicator")
+			}
+			break
+		}
+		switch c := p.s[p.pos]; c {
+		case '_':
+		case '#':
+			*ind = ' '
+		default:
+			*ind = c
+		}
+		p.pos++
+	}
+	return nil
+}
+
+// index parses an optional index in brackets.
+func (p *parser) index() (*span, error) {
+	if !p.accept('[') {
+		return nil, nil
+	}
+	s, err := p.span()
+	if err != nil {
+		return nil, err
+	}
+	return s, p.expect(']')
+}
+
+// span parses a position or a range of positions.
+func (p *parser) span() (*span, error) {
+	start, err := p.position()
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if p.accept('-') {
+		if end, err = p.position(); err != nil {
+			return nil, err
+		}
+	}
+	if start < 0 && end >= 0 || end >= 0 && start > end {
+		return nil, p.errorf("invalid range")
+	}
+	return &span{start: start, end: end}, nil
+}
+
+// position parses a number or "#", which is returned as -1.
+func (p *parser) position() (int, error) {
+	if p.accept('#') {
+		return -1, nil
+	}
+	start, n := p.pos, 0
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		n = n*10 + int(p.s[p.pos]-'0')
+		p.pos++
+		if n > 1<<20 {
+			return 0, p.errorf("position too large")
+		}
+	}
+	if p.pos == start {
+		return 0, p.errorf("expected a position")
+	}
+	return n, nil
+}
+
+// isCode reports, whether c can be a subfield code.
+func isCode(c byte) bool {
+	return c > ' ' && c < 0x7f && c != '{' && c != '}'
+}
+
+// subfield parses a subfield spec following "$".
+func (p *parser) subfield() (ss *subfieldSpec, err error) {
+	if p.pos == len(p.s) || !isCode(p.s[p.pos]) {
+		return nil, p.errorf("expected a subfield code")
+	}
+	ss = &subfieldSpec{from: p.s[p.pos], to: p.s[p.pos]}
+	p.pos++
+	if p.pos+1 < len(p.s) && p.s[p.pos] == '-' && isCode(p.s[p.pos+1]) {
+		ss.to = p.s[p.pos+1]
+		p.pos += 2
+		if ss.from > ss.to {
+			return nil, p.errorf("invalid range of subfield codes")
+		}
+	}
+	if ss.index, err = p.index(); err != nil {
+		return nil, err
+	}
+	if p.accept('/') {
+		if ss.chars, err = p.span(); err != nil {
+			return nil, err
+		}
+	}
+	return ss, p.conditionsOf(&ss.conditions)
+}
+
+// conditionsOf parses any number of conditions in braces and appends them
+// to groups.
+func (p *parser) conditionsOf(groups *[][]*condition) error {
+	for p.accept('{') {
+		var group []*condition
+		for {
+			c, err := p.condition()
+			if err != nil {
+				return err
+			}
+			group = append(group, c)
+			if !p.accept('|') {
+				break
+			}
+		}
+		if err := p.expect('}'); err != nil {
+			return err
+		}
+		*groups = append(*groups, group)
+	}
+	return nil
+}
+
+// condition parses a single condition.
+func (p *parser) condition() (c *condition, err error) {
+	c = &condition{}
+	switch {
+	case p.accept('?'):
+		c.op = "?"
+	case p.peek('!') && !strings.HasPrefix(p.s[p.pos:], "!=") && !strings.HasPrefix(p.s[p.pos:], "!~"):
+		p.pos++
+		c.op = "!"
+	}
+	if c.left, err = p.term(); err != nil || c.op != "" {
+		return c, err
+	}
+	for _, op := range []string{"!=", "!~", "=", "~"} {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			c.op = op
+			c.right, err = p.term()
+			return c, err
+		}
+	}
+	c.op = "?"
+	return c, nil
+}
+
+// term parses a comparison string or a spec.
+func (p *parser) term() (*term, error) {
+	if !p.accept('\\') {
+		relative := p.pos < len(p.s) && strings.IndexByte("$^/", p.s[p.pos]) >= 0
+		spec, err := p.spec(relative)
+		if err != nil {
+			return nil, err
+		}
+		return &term{spec: spec}, nil
+	}
+	var b strings.Builder
+	for p.pos < len(p.s) && strings.IndexByte("}|=!~?", p.s[p.pos]) < 0 {
+		c := p.s[p.pos]
+		p.pos++
+		if c == '\\' && p.pos < len(p.s) {
+			if c = p.s[p.pos]; c == 's' {
+				c = ' '
+			}
+			p.pos++
+		}
+		b.WriteByte(c)
+	}
+	return &term{literal: b.String()}, nil
+}
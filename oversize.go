@@ -0,0 +1,213 @@
+package marc21
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Oversize is the strategy of a Writer for records, that do not fit into the
+// binary format: records longer than 99999 bytes or with fields longer than
+// the field length digits of the directory allow, 9999 bytes in MARC21.
+type Oversize int
+
+const (
+	// OversizeError makes Write return an error for oversize records.
+	OversizeError Oversize = iota
+	// OversizeSplit splits fields, that are too long, into repeated
+	// fields with the same tag and indicators, at subfield boundaries if
+	// possible. Subfields, that are too long on their own, are split into
+	// repeated subfields. Records, that are still too long, are an error.
+	OversizeSplit
+	// OversizeDrop drops fields, that are too long, and then fields of
+	// the lowest priority, until the record fits, see Writer.Priority.
+	OversizeDrop
+)
+
+// DefaultPriority is the default priority of fields, used when dropping
+// fields from oversize records. Control fields, main entries (1XX) and
+// titles (245) are never dropped. Local fields (9XX) go first, followed by
+// electronic locations (856) and notes (5XX), then subjects and added
+// entries (6XX, 7XX) and then all other fields.
+func DefaultPriority(f Field) int {
+	tag := f.GetTag()
+	switch {
+	case len(tag) == 0:
+		return 3
+	case isControlField(f), tag[0] == '1', tag == "245":
+		return -1
+	case tag[0] == '9':
+		return 0
+	case tag == "856", tag[0] == '5':
+		return 1
+	case tag[0] == '6', tag[0] == '7':
+		return 2
+	default:
+		return 3
+	}
+}
+
+// isControlField reports, whether f is a control field.
+func isControlField(f Field) bool {
+	_, ok := f.(*ControlField)
+	return ok
+}
+
+// encodedLength returns the number of bytes of a field in binary format,
+// including the field terminator.
+func encodedLength(f Field, indicators int) int {
+	switch field := f.(type) {
+	case *ControlField:
+		return len(field.Data) + 1
+	case *DataField:
+		n := indicators + 1
+		for _, sf := range field.SubFields {
+			n += 2 + len(sf.Value)
+		}
+		return n
+	}
+	return 0
+}
+
+// fit applies the oversize strategy of the writer to a record. It returns
+// the record itself, if it fits, or a shallow copy with split or dropped
+// fields. The given record is not modified.
+func (w *Writer) fit(record *Record) (*Record, error) {
+	leader := record.Leader
+	if leader == nil {
+		leader = defaultLeader()
+	}
+	indicators, length, start, impl := leader.entryMap()
+	maxLength, entrySize := maxDigits(length), 3+length+start+impl
+	recordLength := func(fields []Field) int {
+		n := 24 + 1 + 1
+		for _, f := range fields {
+			n += entrySize + encodedLength(f, indicators)
+		}
+		return n
+	}
+
+	fields, oversize := record.Fields, false
+	for _, f := range fields {
+		if encodedLength(f, indicators) > maxLength {
+			oversize = true
+			break
+		}
+	}
+	if !oversize && recordLength(fields) <= maxRecordLength {
+		return record, nil
+	}
+
+	switch w.Oversize {
+	case OversizeSplit:
+		fields = make([]Field, 0, len(record.Fields))
+		for _, f := range record.Fields {
+			if encodedLength(f, indicators) <= maxLength {
+				fields = append(fields, f)
+				continue
+			}
+			df, ok := f.(*DataField)
+			if !ok {
+				return nil, fmt.Errorf("control field %s too long: %d bytes", f.GetTag(), encodedLength(f, indicators))
+			}
+			fields = append(fields, splitDataField(df, indicators, maxLength)...)
+		}
+	case OversizeDrop:
+		priority := w.Priority
+		if priority == nil {
+			priority = DefaultPriority
+		}
+		var dropped []Field
+		fields = make([]Field, 0, len(record.Fields))
+		for _, f := range record.Fields {
+			if encodedLength(f, indicators) <= maxLength {
+				fields = append(fields, f)
+				continue
+			}
+			if priority(f) < 0 {
+				return nil, fmt.Errorf("field %s too long: %d bytes", f.GetTag(), encodedLength(f, indicators))
+			}
+			dropped = append(dropped, f)
+		}
+		// Candidates are ordered by priority, the last fields first.
+		candidates := make([]int, 0, len(fields))
+		for i := len(fields) - 1; i >= 0; i-- {
+			if priority(fields[i]) >= 0 {
+				candidates = append(candidates, i)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return priority(fields[candidates[i]]) < priority(fields[candidates[j]])
+		})
+		excess := recordLength(fields) - maxRecordLength
+		drop := make(map[int]bool)
+		for _, i := range candidates {
+			if excess <= 0 {
+				break
+			}
+			drop[i] = true
+			excess -= entrySize + encodedLength(fields[i], indicators)
+		}
+		if len(drop) > 0 {
+			kept := make([]Field, 0, len(fields)-len(drop))
+			for i, f := range fields {
+				if drop[i] {
+					dropped = append(dropped, f)
+				} else {
+					kept = append(kept, f)
+				}
+			}
+			fields = kept
+		}
+		if len(dropped) > 0 && w.OnDrop != nil {
+			w.OnDrop(record, dropped)
+		}
+	}
+	if n := recordLength(fields); n > maxRecordLength {
+		return nil, fmt.Errorf("record too long: %d bytes", n)
+	}
+	return &Record{Leader: record.Leader, Fields: fields}, nil
+}
+
+// splitDataField splits a data field into repeated fields, each at most max
+// bytes long in binary format.
+func splitDataField(df *DataField, indicators, max int) []Field {
+	// room is the space for subfields in a field.
+	room := max - indicators - 1
+	var subfields []*SubField
+	for _, sf := range df.SubFields {
+		if 2+len(sf.Value) <= room {
+			subfields = append(subfields, sf)
+			continue
+		}
+		value := sf.Value
+		for len(value) > 0 {
+			n := room - 2
+			if n >= len(value) {
+				n = len(value)
+			} else {
+				for n > 0 && !utf8.RuneStart(value[n]) {
+					n--
+				}
+				if n == 0 {
+					n = room - 2
+				}
+			}
+			subfields = append(subfields, &SubField{Code: sf.Code, Value: value[:n]})
+			value = value[n:]
+		}
+	}
+	var fields []Field
+	var current *DataField
+	size := 0
+	for _, sf := range subfields {
+		if current == nil || size+2+len(sf.Value) > room {
+			current = &DataField{Tag: df.Tag, Ind1: df.Ind1, Ind2: df.Ind2}
+			fields = append(fields, current)
+			size = 0
+		}
+		current.SubFields = append(current.SubFields, sf)
+		size += 2 + len(sf.Value)
+	}
+	return fields
+}
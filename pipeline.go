@@ -0,0 +1,306 @@
+package marc21
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+// pipelineBatchSize is the number of raw records handed to a worker at once.
+const pipelineBatchSize = 32
+
+// Pipeline decodes a binary MARC stream on several goroutines. One goroutine
+// splits the stream into raw records at the record terminators, a number of
+// workers decode them and the results are delivered in the original order:
+//
+//	p := marc21.NewPipeline(file)
+//	err := p.Each(ctx, func(record *marc21.Record) error {
+//	    ...
+//	})
+//
+// The first error, in stream order, stops the pipeline and is returned.
+type Pipeline struct {
+	// Workers is the number of decoding goroutines. By default, one per
+	// CPU is used.
+	Workers int
+	// ConvertMARC8, if true, converts MARC-8 encoded records to UTF-8, see
+	// Record.DecodeMARC8.
+	ConvertMARC8 bool
+	// OnMARC8Error, if not nil, is called for each record, that could not
+	// be fully converted from MARC-8, see Reader.OnMARC8Error. It is called
+	// in stream order on the goroutine calling Map, before the record is
+	// passed on.
+	OnMARC8Error func(record *Record, err error)
+	// IsControlField, if not nil, decides which tags are decoded as control
+	// fields. By default, IsControlTag is used.
+	IsControlField func(tag string) bool
+	// ISO2709, if true, accepts records of other ISO 2709 formats, see
+	// Reader.ISO2709.
+	ISO2709 bool
+	// CopyValues, if true, gives every tag and value of a record its own
+	// memory, see Reader.CopyValues.
+	CopyValues bool
+	// Tags, if not empty, restricts decoding to fields with these tags,
+	// see Reader.Tags.
+	Tags []string
+	// Limits bound the resources used for reading, see Limits. Use
+	// DefaultLimits for untrusted input.
+	Limits Limits
+	// KeepRaw, if true, keeps the bytes of each record and field as read,
+	// see Reader.KeepRaw.
+	KeepRaw bool
+
+	r io.Reader
+}
+
+// NewPipeline returns a new Pipeline that reads from r.
+func NewPipeline(r io.Reader) *Pipeline {
+	return &Pipeline{r: r}
+}
+
+// pipelineJob is a batch of raw records and, once done is closed, the
+// results of decoding and transforming them. Index and offset locate the
+// first record of the batch in the stream.
+type pipelineJob struct {
+	index   int
+	offset  int64
+	raws    [][]byte
+	results []interface{}
+	// conversions are the MARC-8 conversion errors of the records.
+	conversions []conversionError
+	err         error
+	done        chan struct{}
+}
+
+// conversionError is a MARC-8 conversion error of the record, that yielded
+// the result at the given position of a job.
+type conversionError struct {
+	result int
+	record *Record
+	err    error
+}
+
+// Each decodes all records and calls fn for each of them, in stream order.
+func (p *Pipeline) Each(ctx context.Context, fn func(record *Record) error) error {
+	return p.Map(ctx, nil, func(v interface{}) error {
+		return fn(v.(*Record))
+	})
+}
+
+// Map decodes all records and applies transform to each of them on the
+// worker goroutines. The results are passed to fn in stream order, on the
+// calling goroutine. If transform is nil, the records themselves are passed.
+// Map returns when the stream is exhausted, when decoding, transform or fn
+// fail, or when ctx is done. A read that is blocked in the underlying reader
+// cannot be interrupted and may outlive the call.
+func (p *Pipeline) Map(ctx context.Context, transform func(record *Record) (interface{}, error), fn func(v interface{}) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := p.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	opts := decodeOptions{
+		isControl:  p.IsControlField,
+		iso2709:    p.ISO2709,
+		copyValues: p.CopyValues,
+		limits:     p.Limits,
+		keepRaw:    p.KeepRaw,
+	}
+	if opts.isControl == nil {
+		opts.isControl = IsControlTag
+	}
+	if len(p.Tags) > 0 {
+		opts.keep = oneOf(p.Tags)
+	}
+	var (
+		jobs  = make(chan *pipelineJob)
+		queue = make(chan *pipelineJob, 2*workers)
+	)
+	go p.split(ctx, jobs, queue)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				p.run(job, opts, transform)
+				close(job.done)
+			}
+		}()
+	}
+	for job := range queue {
+		select {
+		case <-job.done:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		conversions := job.conversions
+		for i, v := range job.results {
+			for len(conversions) > 0 && conversions[0].result == i {
+				if p.OnMARC8Error != nil {
+					p.OnMARC8Error(conversions[0].record, conversions[0].err)
+				}
+				conversions = conversions[1:]
+			}
+			if err := fn(v); err != nil {
+				return err
+			}
+		}
+		if job.err != nil {
+			return job.err
+		}
+	}
+	return ctx.Err()
+}
+
+// split reads raw records in batches and sends each batch both to the
+// workers and, to keep the order, to the queue. A read error is delivered
+// as a job of its own after all preceding records.
+func (p *Pipeline) split(ctx context.Context, jobs, queue chan<- *pipelineJob) {
+	defer close(queue)
+	defer close(jobs)
+	br := bufio.NewReader(p.r)
+	var index int
+	var offset int64
+	for {
+		job := &pipelineJob{index: index, offset: offset, done: make(chan struct{})}
+		var err error
+		for len(job.raws) < pipelineBatchSize {
+			var raw []byte
+			max, tooLong := p.Limits.MaxRecordLength, ErrRecordTooLong
+			if total := p.Limits.MaxTotalBytes; total > 0 {
+				rem := total - offset
+				if rem <= 0 {
+					if _, err = br.Peek(1); err == nil {
+						err = ErrInputTooLarge
+					}
+					break
+				}
+				if max <= 0 || int64(max) > rem {
+					max, tooLong = int(rem), ErrInputTooLarge
+				}
+			}
+			raw, err = readRawRecord(br, max, tooLong)
+			if err == ErrRecordTooLong || err == ErrInputTooLarge {
+				err = locate(limitError(err), index, offset)
+				break
+			}
+			if len(raw) > 0 {
+				job.raws = append(job.raws, raw)
+				index++
+				offset += int64(len(raw))
+			}
+			if err != nil {
+				break
+			}
+		}
+		if len(job.raws) > 0 {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case queue <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			job := &pipelineJob{err: err, done: make(chan struct{})}
+			close(job.done)
+			select {
+			case queue <- job:
+			case <-ctx.Done():
+			}
+			return
+		}
+	}
+}
+
+// run decodes and transforms the records of a job. It stops at the first
+// error, keeping the results before it.
+func (p *Pipeline) run(job *pipelineJob, opts decodeOptions, transform func(record *Record) (interface{}, error)) {
+	job.results = make([]interface{}, 0, len(job.raws))
+	offset := job.offset
+	for i, raw := range job.raws {
+		record, err := decodeRaw(raw, opts)
+		if err != nil {
+			job.err = locate(err, job.index+i, offset)
+			return
+		}
+		if p.ConvertMARC8 {
+			if err := record.DecodeMARC8(); err != nil {
+				job.conversions = append(job.conversions, conversionError{len(job.results), record, err})
+			}
+		}
+		offset += int64(len(raw))
+		var v interface{} = record
+		if transform != nil {
+			if v, err = transform(record); err != nil {
+				job.err = err
+				return
+			}
+		}
+		job.results = append(job.results, v)
+	}
+}
+
+// readRawRecord reads the bytes of a single record following the rule of
+// readRaw: records with a usable length in the leader are read with that
+// length, all others up to the record terminator. If max is positive and the
+// record is longer than max bytes, it returns tooLong. At the end of the
+// input, it returns io.EOF, along with the bytes of an incomplete record.
+func readRawRecord(br *bufio.Reader, max int, tooLong error) ([]byte, error) {
+	head, err := br.Peek(24)
+	if err != nil {
+		return readTerminated(br, nil, max, tooLong)
+	}
+	n, err := strconv.Atoi(string(head[:5]))
+	if err != nil || n < minRecordLength || n >= maxRecordLength {
+		return readTerminated(br, nil, max, tooLong)
+	}
+	if max > 0 && n > max {
+		return nil, tooLong
+	}
+	raw := make([]byte, n)
+	k, err := io.ReadFull(br, raw)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return raw[:k], err
+}
+
+// decodeRaw decodes a single record, given as raw bytes, which have been
+// read with readRawRecord.
+func decodeRaw(raw []byte, opts decodeOptions) (*Record, error) {
+	truncated := newParseError(Truncated, io.ErrUnexpectedEOF)
+	if len(raw) < 24 {
+		return nil, truncated
+	}
+	leader, err := parseLeader(raw[:24], opts.iso2709)
+	if err != nil {
+		return nil, err
+	}
+	if leader.Length >= minRecordLength && leader.Length < maxRecordLength {
+		if len(raw) < leader.Length {
+			return nil, truncated
+		}
+	} else if raw[len(raw)-1] != RT {
+		return nil, truncated
+	}
+	if opts.keepRaw {
+		leader.setRaw(raw)
+	}
+	record := &Record{Leader: leader}
+	if err := record.decode(string(raw[24:]), opts); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,145 @@
+package marc21
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPipelineEach(t *testing.T) {
+	var want []string
+	r := NewReader(openTestMARC(t))
+	for r.Scan() {
+		want = append(want, r.Record().String())
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	for _, workers := range []int{1, 2, 7} {
+		p := NewPipeline(openTestMARC(t))
+		p.Workers = workers
+		var got []string
+		err := p.Each(context.Background(), func(record *Record) error {
+			got = append(got, record.String())
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("workers %d, records, got %d, want %d", workers, len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("workers %d, record %d out of order", workers, i)
+			}
+		}
+	}
+}
+
+func TestPipelineMap(t *testing.T) {
+	p := NewPipeline(openTestMARC(t))
+	p.Workers = 4
+	var ids []string
+	err := p.Map(context.Background(), func(record *Record) (interface{}, error) {
+		return record.Identifier(), nil
+	}, func(v interface{}) error {
+		ids = append(ids, v.(string))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 85 || ids[0] != "50001" {
+		t.Errorf("ids, got %d starting with %q, want 85 starting with %q", len(ids), ids[0], "50001")
+	}
+}
+
+func TestPipelineError(t *testing.T) {
+	p := NewPipeline(bytes.NewReader(corruptStream(t)))
+	var ids []string
+	err := p.Each(context.Background(), func(record *Record) error {
+		ids = append(ids, record.Identifier())
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("Each, got nil, want some error")
+	}
+	if len(ids) != 1 || ids[0] != "50001" {
+		t.Errorf("ids, got %v, want %v", ids, []string{"50001"})
+	}
+
+	stop := errors.New("stop")
+	p = NewPipeline(openTestMARC(t))
+	var n int
+	err = p.Each(context.Background(), func(record *Record) error {
+		if n++; n == 10 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 10 {
+		t.Errorf("callback error, got %v after %d records, want %v after 10", err, n, stop)
+	}
+}
+
+func TestPipelineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPipeline(openTestMARC(t))
+	var n int
+	err := p.Each(ctx, func(record *Record) error {
+		if n++; n == 5 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Each, got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPipelineLeaderLength(t *testing.T) {
+	first := buildRecord("001000200000245000800002", "1\x1e10\x1faA\x1dB\x1e")
+	second := buildRecord("001000200000", "2\x1e")
+	withLength := func(b []byte, n int) []byte {
+		b = append([]byte{}, b...)
+		copy(b, fmt.Sprintf("%05d", n))
+		return b
+	}
+	var cases = []struct {
+		about string
+		in    []byte
+	}{
+		{"terminator in value", append(append([]byte{}, first...), second...)},
+		{"length of two records", append(withLength(second, 2*len(second)), second...)},
+		{"length too short", append(withLength(second, len(second)-1), second...)},
+		{"length too long", append(withLength(second, len(second)+1), second...)},
+		{"length 00000", append(withLength(second, 0), second...)},
+		{"truncated", withLength(second, len(second)+1)},
+	}
+	result := func(ids []string, err error) string {
+		if pe, ok := err.(*ParseError); ok {
+			return fmt.Sprintf("%v %v", ids, pe.Kind)
+		}
+		return fmt.Sprintf("%v %v", ids, err)
+	}
+	for _, c := range cases {
+		var ids []string
+		r := NewReader(bytes.NewReader(c.in))
+		for r.Scan() {
+			ids = append(ids, r.Record().Identifier())
+		}
+		want := result(ids, r.Err())
+
+		ids = nil
+		err := NewPipeline(bytes.NewReader(c.in)).Each(context.Background(), func(record *Record) error {
+			ids = append(ids, record.Identifier())
+			return nil
+		})
+		if got := result(ids, err); got != want {
+			t.Errorf("%s, got %s, want %s as read by a Reader", c.about, got, want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package marc21
+
+import "strings"
+
+// setRaw keeps the 24 bytes of a leader as read and a copy of its values,
+// so that Bytes can tell changed values from unchanged ones.
+func (leader *Leader) setRaw(b []byte) {
+	orig := *leader
+	leader.raw, leader.orig = string(b[:24]), &orig
+}
+
+// changed reports, whether any value of the leader differs from the values
+// read, or whether it has not been read at all.
+func (leader *Leader) changed() bool {
+	if leader.orig == nil {
+		return true
+	}
+	l := *leader
+	l.raw, l.orig = "", nil
+	return l != *leader.orig
+}
+
+// keepRaw keeps the bytes of a decoded record and its list of fields, unless
+// some fields have been skipped.
+func (record *Record) keepRaw(body string, opts decodeOptions) {
+	if opts.keep != nil || record.Leader.raw == "" {
+		return
+	}
+	record.raw = record.Leader.raw + body
+	record.fields = append([]Field(nil), record.Fields...)
+}
+
+// setFieldRaw keeps the bytes of a decoded field.
+func setFieldRaw(f Field, raw string) {
+	switch field := f.(type) {
+	case *ControlField:
+		field.raw = raw
+	case *DataField:
+		field.raw = raw
+	}
+}
+
+// Raw returns the bytes the record was decoded from, if it has been read
+// with KeepRaw and all of its fields were decoded, otherwise nil. The bytes
+// are not updated, when the record is changed.
+func (record *Record) Raw() []byte {
+	if record.raw == "" {
+		return nil
+	}
+	return []byte(record.raw)
+}
+
+// MarkDirty marks the record as changed, so that it is encoded anew when
+// written, even if it is equal to its raw bytes. Its unchanged fields are
+// still written as read, unless they are marked dirty themselves.
+func (record *Record) MarkDirty() {
+	record.dirty = true
+}
+
+// Dirty reports, whether the record has to be encoded anew when written,
+// because it has no raw bytes, has been marked dirty, or its leader or
+// fields have changed since it was read.
+func (record *Record) Dirty() bool {
+	if record.dirty || record.raw == "" || record.Leader == nil ||
+		record.Leader.raw != record.raw[:24] || record.Leader.changed() ||
+		len(record.Fields) != len(record.fields) {
+		return true
+	}
+	indicators, _, _, _ := record.Leader.entryMap()
+	for i, f := range record.Fields {
+		if f != record.fields[i] || rawField(f, indicators) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Raw returns the bytes of the field as read, including the field
+// terminator, or nil, if the field has not been read with KeepRaw. The bytes
+// are not updated, when the field is changed.
+func (cf *ControlField) Raw() []byte {
+	if cf.raw == "" {
+		return nil
+	}
+	return []byte(cf.raw)
+}
+
+// MarkDirty marks the field as changed, so that it is encoded anew when
+// written, even if it is equal to its raw bytes.
+func (cf *ControlField) MarkDirty() {
+	cf.dirty = true
+}
+
+// Raw returns the bytes of the field as read, including the indicators and
+// the field terminator, or nil, if the field has not been read with KeepRaw.
+// The bytes are not updated, when the field is changed.
+func (df *DataField) Raw() []byte {
+	if df.raw == "" {
+		return nil
+	}
+	return []byte(df.raw)
+}
+
+// MarkDirty marks the field as changed, so that it is encoded anew when
+// written, even if it is equal to its raw bytes.
+func (df *DataField) MarkDirty() {
+	df.dirty = true
+}
+
+// rawField returns the raw bytes of a field, if it is not dirty and its raw
+// bytes, decoded with the given number of indicators, are equal to it.
+// Otherwise, it returns an empty string.
+func rawField(f Field, indicators int) string {
+	switch field := f.(type) {
+	case *ControlField:
+		if !field.dirty && field.raw != "" && field.raw[:len(field.raw)-1] == field.Data {
+			return field.raw
+		}
+	case *DataField:
+		if !field.dirty && field.raw != "" && field.equalsRaw(indicators) {
+			return field.raw
+		}
+	}
+	return ""
+}
+
+// equalsRaw reports, whether the raw bytes of a data field decode to its
+// indicators and subfields, see decode.
+func (df *DataField) equalsRaw(indicators int) bool {
+	data := df.raw
+	if len(data) < indicators+1 || data[len(data)-1] != RS {
+		return false
+	}
+	ind1, ind2 := byte(' '), byte(' ')
+	if indicators > 0 {
+		ind1 = data[0]
+	}
+	if indicators > 1 {
+		ind2 = data[1]
+	}
+	if ind1 != df.Ind1 || ind2 != df.Ind2 {
+		return false
+	}
+	data = data[indicators : len(data)-1]
+	i := 0
+	for len(data) > 0 {
+		var s string
+		if j := strings.IndexByte(data[1:], DELIM); j >= 0 {
+			s, data = data[:j+1], data[j+1:]
+		} else {
+			s, data = data, ""
+		}
+		if s[0] == DELIM {
+			s = s[1:]
+		}
+		if len(s) == 0 {
+			continue
+		}
+		if i == len(df.SubFields) || df.SubFields[i] == nil ||
+			df.SubFields[i].Code != s[0] || df.SubFields[i].Value != s[1:] {
+			return false
+		}
+		i++
+	}
+	return i == len(df.SubFields)
+}
+
+// rawMARC8Field returns a field decoded from the raw bytes of f, if
+// converting them from MARC-8 yields f, so that an unchanged field keeps its
+// original MARC-8 encoding. Otherwise, it returns nil.
+func rawMARC8Field(f Field, indicators int) Field {
+	switch field := f.(type) {
+	case *ControlField:
+		if field.dirty || field.raw == "" {
+			return nil
+		}
+		v := field.raw[:len(field.raw)-1]
+		if s, err := decodeMARC8String(v); err == nil && s == field.Data {
+			return &ControlField{Tag: field.Tag, Data: v, raw: field.raw}
+		}
+	case *DataField:
+		if field.dirty || field.raw == "" {
+			return nil
+		}
+		df := &DataField{Tag: field.Tag, raw: field.raw}
+		pool := make([]SubField, 0, strings.Count(field.raw, string(rune(DELIM))))
+		if df.decode(field.raw, indicators, &pool, new([]*SubField)) != nil ||
+			df.Ind1 != field.Ind1 || df.Ind2 != field.Ind2 || len(df.SubFields) != len(field.SubFields) {
+			return nil
+		}
+		for i, sf := range df.SubFields {
+			s, err := decodeMARC8String(sf.Value)
+			if err != nil || field.SubFields[i] == nil || field.SubFields[i].Code != sf.Code || field.SubFields[i].Value != s {
+				return nil
+			}
+		}
+		return df
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package marc21
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+// quirkyRecord returns a record, that does not survive decoding and encoding
+// unchanged: leader/22-23 are blank, the directory is not in data order,
+// there is a gap in the data section and an empty subfield.
+func quirkyRecord() []byte {
+	b := buildRecord("001000600000245001100017100000900006",
+		"12345\x1e1 \x1faName\x1exx10\x1f\x1faTitle\x1e")
+	b[22], b[23] = ' ', ' '
+	return b
+}
+
+func TestKeepRaw(t *testing.T) {
+	b := quirkyRecord()
+	record, err := NewReader(bytes.NewReader(b)).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := record.MarshalBinary(); bytes.Equal(out, b) {
+		t.Fatalf("without KeepRaw, got the original bytes")
+	}
+	if record.Raw() != nil || !record.Dirty() {
+		t.Errorf("without KeepRaw, got raw bytes %q and dirty %v", record.Raw(), record.Dirty())
+	}
+
+	r := NewReader(bytes.NewReader(b))
+	r.KeepRaw = true
+	record, err = r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(record.Raw(), b) || record.Dirty() {
+		t.Errorf("got raw bytes %q and dirty %v, want %q and false", record.Raw(), record.Dirty(), b)
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), b) {
+		t.Errorf("unchanged, got %q, want %q", buf.Bytes(), b)
+	}
+	if got := record.Leader.String(); got != string(b[:24]) {
+		t.Errorf("leader, got %q, want %q", got, b[:24])
+	}
+
+	name := record.GetSubFields("100", 'a')[0]
+	name.Value = "Other"
+	if !record.Dirty() {
+		t.Errorf("changed subfield, got dirty false")
+	}
+	out, err := record.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out, []byte("10\x1f\x1faTitle\x1e")) || !bytes.Equal(out[22:24], []byte("  ")) {
+		t.Errorf("changed, got %q, want unchanged field and leader/22-23 as read", out)
+	}
+	reread, err := ReadRecord(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := reread.GetSubFields("100", 'a')[0].Value; v != "Other" {
+		t.Errorf("changed, got %q, want %q", v, "Other")
+	}
+	name.Value = "Name"
+	if record.Dirty() {
+		t.Errorf("changed back, got dirty true")
+	}
+
+	record.MarkDirty()
+	if out, _ := record.MarshalBinary(); bytes.Equal(out, b) || !bytes.Contains(out, []byte("\x1f\x1f")) {
+		t.Errorf("record marked dirty, got %q", out)
+	}
+	record.GetFields("245")[0].(*DataField).MarkDirty()
+	if out, _ := record.MarshalBinary(); bytes.Contains(out, []byte("\x1f\x1f")) {
+		t.Errorf("field marked dirty, got %q", out)
+	}
+
+	record.Leader.Status = 'c'
+	if out := record.Leader.Bytes(); out[5] != 'c' || string(out[22:]) != "  " {
+		t.Errorf("changed leader, got %q", out)
+	}
+}
+
+func TestKeepRawFieldsSkipped(t *testing.T) {
+	r := NewReader(bytes.NewReader(quirkyRecord()))
+	r.KeepRaw, r.Tags = true, []string{"001", "245"}
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Raw() != nil || !record.Dirty() {
+		t.Errorf("got raw bytes %q and dirty %v, want nil and true", record.Raw(), record.Dirty())
+	}
+	out, err := record.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out, []byte("10\x1f\x1faTitle\x1e")) || bytes.Contains(out, []byte("Name")) {
+		t.Errorf("got %q", out)
+	}
+}
+
+// TestKeepRawStream writes all test records read with KeepRaw, with the
+// Reader, LazyRecords and a Pipeline.
+func TestKeepRawStream(t *testing.T) {
+	b, err := ioutil.ReadFile("fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []*Record
+	r := NewReader(bytes.NewReader(b))
+	r.KeepRaw = true
+	for r.Scan() {
+		records = append(records, r.Record())
+	}
+	r = NewReader(bytes.NewReader(b))
+	r.KeepRaw = true
+	for {
+		lr, err := r.NextLazy()
+		if err != nil {
+			break
+		}
+		lr.GetFields("245")
+		record, _ := lr.Record()
+		records = append(records, record)
+	}
+	p := NewPipeline(bytes.NewReader(b))
+	p.KeepRaw = true
+	p.Each(context.Background(), func(record *Record) error {
+		records = append(records, record)
+		return nil
+	})
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, record := range records {
+		if record.Dirty() {
+			t.Fatalf("record %s is dirty", record.Identifier())
+		}
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := bytes.Repeat(b, 3); !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("got %d bytes, want %d bytes as read", buf.Len(), len(want))
+	}
+}
+
+// TestKeepRawMARC8 checks, that unchanged fields of a record converted from
+// MARC-8 keep their encoding, even if the encoder would write them
+// differently.
+func TestKeepRawMARC8(t *testing.T) {
+	b := buildRecord("001000600000245002300006100001300029",
+		"12345\x1e10\x1fa\x1b(BTitle\x1fbC\xe2eleste\x1e1 \x1faC\xe2eleste\x1e")
+	b[9] = ' '
+	r := NewReader(bytes.NewReader(b))
+	r.KeepRaw, r.ConvertMARC8 = true, true
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetSubFields("245", 'a')[0].Value; v != "Title" {
+		t.Fatalf("got %q, want %q", v, "Title")
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.MARC8 = NewMARC8Encoder()
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), b) {
+		t.Errorf("got %q, want %q", buf.Bytes(), b)
+	}
+
+	record.GetSubFields("100", 'a')[0].Value = "Céleste!"
+	buf.Reset()
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("\x1b(BTitle")) || !bytes.Contains(buf.Bytes(), []byte("C\xe2eleste!")) {
+		t.Errorf("changed, got %q", buf.Bytes())
+	}
+}
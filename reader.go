@@ -0,0 +1,367 @@
+package marc21
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// countingReader keeps track of the number of bytes read. If capture is set,
+// all bytes read are recorded in raw. Bytes can be pushed back with unread.
+// If limit is positive, reading more than limit bytes in total fails with
+// ErrInputTooLarge.
+type countingReader struct {
+	r       *bufio.Reader
+	pending []byte
+	n       int64
+	limit   int64
+	capture bool
+	raw     []byte
+}
+
+// remaining returns the number of bytes, that may still be read, or -1 if
+// there is no limit. If the limit has been reached, but there is more
+// input, it returns ErrInputTooLarge.
+func (cr *countingReader) remaining() (int64, error) {
+	if cr.limit <= 0 {
+		return -1, nil
+	}
+	rem := cr.limit - cr.n
+	if rem > 0 {
+		return rem, nil
+	}
+	if len(cr.pending) == 0 {
+		if _, err := cr.r.Peek(1); err != nil {
+			return 0, err
+		}
+	}
+	return 0, ErrInputTooLarge
+}
+
+// Read reads from the underlying reader and counts the bytes read.
+func (cr *countingReader) Read(p []byte) (n int, err error) {
+	rem, err := cr.remaining()
+	if err != nil {
+		return 0, err
+	}
+	if rem >= 0 && int64(len(p)) > rem {
+		p = p[:rem]
+	}
+	if len(cr.pending) > 0 {
+		n = copy(p, cr.pending)
+		cr.pending = cr.pending[n:]
+	} else {
+		n, err = cr.r.Read(p)
+	}
+	cr.n += int64(n)
+	if cr.capture {
+		cr.raw = append(cr.raw, p[:n]...)
+	}
+	return
+}
+
+// unread pushes back bytes, so they are returned by the next read.
+func (cr *countingReader) unread(b []byte) {
+	cr.pending = append(append([]byte{}, b...), cr.pending...)
+	cr.n -= int64(len(b))
+}
+
+// readTerminated reads up to and including the next record terminator. If
+// max is positive, it stops after more than max bytes with
+// ErrRecordTooLong.
+func (cr *countingReader) readTerminated(max int) (b []byte, err error) {
+	rem, err := cr.remaining()
+	if err != nil {
+		return nil, err
+	}
+	tooLong := ErrRecordTooLong
+	if rem >= 0 && (max <= 0 || int64(max) > rem) {
+		max, tooLong = int(rem), ErrInputTooLarge
+	}
+	if i := bytes.IndexByte(cr.pending, RT); i >= 0 {
+		b = append(b, cr.pending[:i+1]...)
+		cr.pending = cr.pending[i+1:]
+	} else {
+		b = append(b, cr.pending...)
+		cr.pending = nil
+		b, err = readTerminated(cr.r, b, max, tooLong)
+	}
+	cr.n += int64(len(b))
+	if cr.capture {
+		cr.raw = append(cr.raw, b...)
+	}
+	return b, err
+}
+
+// skipTerminated reads up to and including the next record terminator, like
+// readTerminated, but keeps only the first keep bytes, if keep is positive,
+// so that the length of a skipped record is not limited.
+func (cr *countingReader) skipTerminated(keep int) (b []byte, err error) {
+	rem, err := cr.remaining()
+	if err != nil {
+		return nil, err
+	}
+	var n int64
+	if i := bytes.IndexByte(cr.pending, RT); i >= 0 {
+		b = append(b, cr.pending[:i+1]...)
+		cr.pending = cr.pending[i+1:]
+		n = int64(len(b))
+	} else {
+		b = append(b, cr.pending...)
+		n = int64(len(cr.pending))
+		cr.pending = nil
+		for {
+			var chunk []byte
+			chunk, err = cr.r.ReadSlice(RT)
+			n += int64(len(chunk))
+			if keep <= 0 || len(b) < keep {
+				b = append(b, chunk...)
+			}
+			if rem >= 0 && n > rem {
+				err = ErrInputTooLarge
+				break
+			}
+			if err != bufio.ErrBufferFull {
+				break
+			}
+		}
+	}
+	if keep > 0 && len(b) > keep {
+		b = b[:keep]
+	}
+	cr.n += n
+	return b, err
+}
+
+// SkippedRecord describes a record, that could not be decoded and that was
+// skipped by a lenient reader.
+type SkippedRecord struct {
+	// Index is the zero based position of the record in the stream,
+	// counting both valid and skipped records.
+	Index int
+	// Offset is the byte offset of the record in the stream.
+	Offset int64
+	// Raw contains the bytes of the skipped record, including the record
+	// terminator, if any. It is cut off after Limits.MaxRecordLength
+	// bytes.
+	Raw []byte
+	// Err is the error that caused the record to be skipped.
+	Err error
+}
+
+// Reader reads records from a buffered binary MARC stream. It can be used
+// like a scanner:
+//
+//	r := marc21.NewReader(file)
+//	for r.Scan() {
+//	    record := r.Record()
+//	}
+//	if err := r.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// or by calling Next until it returns io.EOF.
+type Reader struct {
+	// Lenient, if true, makes the reader skip records that cannot be
+	// decoded by scanning forward to the next record terminator, instead
+	// of returning an error.
+	Lenient bool
+	// OnSkip, if not nil, is called for each record skipped in lenient
+	// mode.
+	OnSkip func(skipped *SkippedRecord)
+	// ConvertMARC8, if true, converts MARC-8 encoded records to UTF-8, see
+	// Record.DecodeMARC8.
+	ConvertMARC8 bool
+	// OnMARC8Error, if not nil, is called for each record, that could not
+	// be fully converted from MARC-8, with the first conversion error. The
+	// record is returned anyway, with the characters that could not be
+	// converted replaced by U+FFFD.
+	OnMARC8Error func(record *Record, err error)
+	// IsControlField, if not nil, decides which tags are decoded as control
+	// fields. By default, IsControlTag is used.
+	IsControlField func(tag string) bool
+	// ISO2709, if true, accepts records of other ISO 2709 formats, like
+	// UNIMARC or danMARC2, by honouring the indicator count (leader/10)
+	// and the entry map (leader/20-22) instead of requiring the MARC21
+	// values. Subfield codes must still be a single byte.
+	ISO2709 bool
+	// CopyValues, if true, gives every tag and value of a record its own
+	// memory. By default, they are views into a single string per record,
+	// which is faster, but keeps the whole record in memory as long as any
+	// of its values is referenced.
+	CopyValues bool
+	// Tags, if not empty, restricts decoding to fields with these tags.
+	// All other fields are skipped, as if they were not in the record.
+	Tags []string
+	// Limits bound the resources used for reading, see Limits. Use
+	// DefaultLimits for untrusted input.
+	Limits Limits
+	// KeepRaw, if true, keeps the bytes of each record and field as read,
+	// so that writing an unchanged record reproduces them exactly, see
+	// Record.Raw and Record.Dirty. Of a changed record, the unchanged
+	// fields and leader values are written as read.
+	KeepRaw bool
+
+	buf    []byte
+	cr     countingReader
+	record *Record
+	err    error
+	count  int
+	index  int
+	// start is the byte offset of the last record returned.
+	start int64
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{cr: countingReader{r: bufio.NewReader(r)}}
+}
+
+// Next returns the next record from the stream. It returns io.EOF, if there
+// are no more records.
+func (r *Reader) Next() (*Record, error) {
+	var record *Record
+	err := r.next(func(leader *Leader, body string, opts decodeOptions) error {
+		record = &Record{Leader: leader}
+		return record.decode(body, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.ConvertMARC8 {
+		if err := record.DecodeMARC8(); err != nil && r.OnMARC8Error != nil {
+			r.OnMARC8Error(record, err)
+		}
+	}
+	r.record = record
+	return record, nil
+}
+
+// NextLazy returns the next record from the stream as a LazyRecord, whose
+// fields are only decoded when accessed. It returns io.EOF, if there are no
+// more records. Records read with NextLazy are not available through the
+// Record method.
+func (r *Reader) NextLazy() (*LazyRecord, error) {
+	var lr *LazyRecord
+	err := r.next(func(leader *Leader, body string, opts decodeOptions) (err error) {
+		lr, err = newLazyRecord(leader, body, opts, r.ConvertMARC8)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lr, nil
+}
+
+// next reads the next record and passes it to decode. In lenient mode,
+// records that cannot be read or decoded are skipped.
+func (r *Reader) next(decode func(leader *Leader, body string, opts decodeOptions) error) error {
+	isControl := r.IsControlField
+	if isControl == nil {
+		isControl = IsControlTag
+	}
+	opts := decodeOptions{
+		isControl:  isControl,
+		iso2709:    r.ISO2709,
+		copyValues: r.CopyValues,
+		limits:     r.Limits,
+		keepRaw:    r.KeepRaw,
+		buf:        &r.buf,
+	}
+	r.cr.limit = r.Limits.MaxTotalBytes
+	if len(r.Tags) > 0 {
+		opts.keep = oneOf(r.Tags)
+	}
+	for {
+		if r.err != nil {
+			return r.err
+		}
+		offset := r.cr.n
+		r.cr.capture, r.cr.raw = r.Lenient, nil
+		leader, body, err := readRaw(&r.cr, opts)
+		r.cr.capture = false
+		if err == nil {
+			err = decode(leader, body, opts)
+		}
+		if err == nil {
+			r.count++
+			r.index++
+			r.start = offset
+			return nil
+		}
+		err = locate(err, r.index, offset)
+		if !r.Lenient || (err == io.EOF && r.cr.n == offset) || isLimit(err, ErrInputTooLarge) {
+			r.err = err
+			return err
+		}
+		raw, serr := r.skip()
+		if serr != nil {
+			r.err = serr
+			if serr != io.EOF {
+				r.err = locate(readError(serr), r.index, offset)
+			}
+		}
+		if r.OnSkip != nil {
+			r.OnSkip(&SkippedRecord{Index: r.index, Offset: offset, Raw: raw, Err: err})
+		}
+		r.index++
+	}
+}
+
+// skip moves the reader past the end of a broken record and returns the raw
+// bytes of that record. It returns io.EOF, if the end of the stream is
+// reached, or ErrInputTooLarge.
+func (r *Reader) skip() ([]byte, error) {
+	raw := r.cr.raw
+	if i := bytes.IndexByte(raw, RT); i >= 0 {
+		r.cr.unread(raw[i+1:])
+		return raw[:i+1], nil
+	}
+	keep := r.Limits.MaxRecordLength
+	if keep > 0 {
+		if keep -= len(raw); keep <= 0 {
+			keep = 1
+		}
+	}
+	rest, err := r.cr.skipTerminated(keep)
+	return append(raw, rest...), err
+}
+
+// Scan advances the reader to the next record, which will then be available
+// through the Record method. It returns false when the stream is exhausted or
+// an error occurred.
+func (r *Reader) Scan() bool {
+	_, err := r.Next()
+	return err == nil
+}
+
+// Record returns the most recent record read by a call to Scan or Next.
+func (r *Reader) Record() *Record {
+	return r.record
+}
+
+// Err returns the first non-EOF error that was encountered by the reader.
+func (r *Reader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Count returns the number of records read so far.
+func (r *Reader) Count() int {
+	return r.count
+}
+
+// Offset returns the number of bytes consumed from the underlying reader,
+// which is the byte offset of the next record in the stream.
+func (r *Reader) Offset() int64 {
+	return r.cr.n
+}
+
+// RecordOffset returns the byte offset of the last record returned by Next
+// or NextLazy. Unlike Offset before the call, it does not include the bytes
+// of records skipped in lenient mode.
+func (r *Reader) RecordOffset() int64 {
+	return r.start
+}
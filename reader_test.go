@@ -0,0 +1,275 @@
+package marc21
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestReaderNext(t *testing.T) {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	r := NewReader(data)
+	for {
+		_, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Count() != 85 {
+		t.Errorf("Count, got %v, want %v", r.Count(), 85)
+	}
+	fi, err := os.Stat("fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Offset() != fi.Size() {
+		t.Errorf("Offset, got %v, want %v", r.Offset(), fi.Size())
+	}
+}
+
+func TestReaderScan(t *testing.T) {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	r := NewReader(data)
+	var ids []string
+	for r.Scan() {
+		ids = append(ids, r.Record().Identifier())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 85 {
+		t.Fatalf("records, got %v, want %v", len(ids), 85)
+	}
+	if ids[0] != "50001" {
+		t.Errorf("Identifier, got %v, want %v", ids[0], "50001")
+	}
+}
+
+func TestReaderErr(t *testing.T) {
+	file, err := os.Open("fixtures/r3.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	r := NewReader(file)
+	if r.Scan() {
+		t.Errorf("Scan, got true, want false")
+	}
+	if r.Err() == nil {
+		t.Errorf("Err, got nil, want some error")
+	}
+}
+
+// corruptStream returns the first three records of the test file, with the
+// directory of the second record broken.
+func corruptStream(t *testing.T) []byte {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	var buf bytes.Buffer
+	for i := 0; i < 3; i++ {
+		record, err := ReadRecord(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := record.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			copy(b[24+3:24+7], "XXXX")
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+func TestReaderLenient(t *testing.T) {
+	b := corruptStream(t)
+
+	r := NewReader(bytes.NewReader(b))
+	if _, err := r.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Next(); err == nil {
+		t.Fatalf("Next, got nil, want some error")
+	}
+
+	var skipped []*SkippedRecord
+	r = NewReader(bytes.NewReader(b))
+	r.Lenient = true
+	r.OnSkip = func(s *SkippedRecord) {
+		skipped = append(skipped, s)
+	}
+	var ids []string
+	for r.Scan() {
+		ids = append(ids, r.Record().Identifier())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "50001" || ids[1] != "150001" {
+		t.Errorf("ids, got %v, want %v", ids, []string{"50001", "150001"})
+	}
+	if len(skipped) != 1 {
+		t.Fatalf("skipped, got %d, want %d", len(skipped), 1)
+	}
+	s := skipped[0]
+	if s.Index != 1 {
+		t.Errorf("Index, got %v, want %v", s.Index, 1)
+	}
+	if s.Offset != 819 {
+		t.Errorf("Offset, got %v, want %v", s.Offset, 819)
+	}
+	if !bytes.Equal(s.Raw, b[819:819+len(s.Raw)]) || s.Raw[len(s.Raw)-1] != RT {
+		t.Errorf("Raw, got %q", s.Raw)
+	}
+	if s.Err == nil {
+		t.Errorf("Err, got nil, want some error")
+	}
+	if r.Offset() != int64(len(b)) {
+		t.Errorf("Offset, got %v, want %v", r.Offset(), len(b))
+	}
+}
+
+func TestReaderLenientTruncated(t *testing.T) {
+	b := corruptStream(t)
+	b = b[:len(b)-10]
+
+	var skipped int
+	r := NewReader(bytes.NewReader(b))
+	r.Lenient = true
+	r.OnSkip = func(s *SkippedRecord) {
+		skipped++
+	}
+	var count int
+	for r.Scan() {
+		count++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || skipped != 2 {
+		t.Errorf("count and skipped, got %d and %d, want 1 and 2", count, skipped)
+	}
+}
+
+func TestReaderISO2709(t *testing.T) {
+	// A record with a single indicator and directory entries of 3+5+6+2
+	// bytes, as declared by the entry map 562.
+	dir := "001" + "00004" + "000000" + "00" + "200" + "00009" + "000004" + "00" + "\x1e"
+	data := "123\x1e" + "1\x1faTitle\x1e" + "\x1d"
+	base := 24 + len(dir)
+	b := []byte(fmt.Sprintf("%05dnam  12%05d   5620", base+len(data), base) + dir + data)
+
+	if _, err := NewReader(bytes.NewReader(b)).Next(); err == nil {
+		t.Errorf("MARC21 mode, got nil error")
+	}
+	r := NewReader(bytes.NewReader(b))
+	r.ISO2709 = true
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Leader.IndicatorCount != 1 || record.Leader.LengthOfImplementationDefined != 2 {
+		t.Errorf("leader, got %+v", record.Leader)
+	}
+	if v := record.GetSubFields("200", 'a'); len(v) != 1 || v[0].Value != "Title" {
+		t.Errorf("200 $a, got %v", v)
+	}
+	df := record.GetFields("200")[0].(*DataField)
+	if df.Ind1 != '1' || df.Ind2 != ' ' {
+		t.Errorf("indicators, got %q %q, want '1' ' '", df.Ind1, df.Ind2)
+	}
+	out, err := record.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, b) {
+		t.Errorf("MarshalBinary, got %q, want %q", out, b)
+	}
+}
+
+func TestReaderISO2709Unsupported(t *testing.T) {
+	var cases = []string{
+		"00026nam  3200025   4500\x1e\x1d",
+		"00026nam  2300025   4500\x1e\x1d",
+		"00026nam  2200025   0000\x1e\x1d",
+	}
+	for _, c := range cases {
+		r := NewReader(bytes.NewReader([]byte(c)))
+		r.ISO2709 = true
+		if _, err := r.Next(); err == nil {
+			t.Errorf("Next(%q), got nil error", c)
+		}
+	}
+}
+
+func BenchmarkReader(b *testing.B) {
+	data, err := ioutil.ReadFile("fixtures/test.mrc")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+		for r.Scan() {
+		}
+		if r.Err() != nil {
+			b.Fatal(r.Err())
+		}
+	}
+}
+
+func TestReaderCopyValues(t *testing.T) {
+	var records [2][]*Record
+	for i, copyValues := range []bool{false, true} {
+		r := NewReader(openTestMARC(t))
+		r.CopyValues = copyValues
+		for r.Scan() {
+			records[i] = append(records[i], r.Record())
+		}
+		if r.Err() != nil {
+			t.Fatal(r.Err())
+		}
+	}
+	if len(records[0]) != len(records[1]) {
+		t.Fatalf("count, got %d, want %d", len(records[1]), len(records[0]))
+	}
+	for i := range records[0] {
+		if records[0][i].String() != records[1][i].String() {
+			t.Errorf("record %d differs with CopyValues", i)
+		}
+	}
+}
+
+func TestReaderSubFieldsIndependent(t *testing.T) {
+	record, err := NewReader(openTestMARC(t)).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields []*DataField
+	for _, f := range record.Fields {
+		if df, ok := f.(*DataField); ok {
+			fields = append(fields, df)
+		}
+	}
+	want := fields[1].String()
+	fields[0].SubFields = append(fields[0].SubFields, &SubField{Code: 'z', Value: "added"})
+	if got := fields[1].String(); got != want {
+		t.Errorf("appending to a field changed the next field, got %s, want %s", got, want)
+	}
+}
@@ -3,7 +3,9 @@ package marc21
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
 )
 
@@ -12,51 +14,334 @@ import (
 type Record struct {
 	Leader *Leader `xml:"leader"`
 	Fields []Field
+
+	// raw and fields are the record and its fields as read with KeepRaw,
+	// see Raw and Dirty.
+	raw    string
+	fields []Field
+	dirty  bool
 }
 
-// ReadRecord returns a single MARC record from a reader.
+// ReadRecord returns a single MARC record from a reader. The reader is not
+// buffered and is read in small chunks; use a Reader to decode a stream of
+// records.
 func ReadRecord(reader io.Reader) (record *Record, err error) {
+	return readRecord(reader, decodeOptions{isControl: IsControlTag})
+}
+
+// decodeOptions control how binary records are decoded.
+type decodeOptions struct {
+	// isControl decides, which fields are decoded as control fields.
+	isControl func(tag string) bool
+	// iso2709 enables the generic ISO 2709 mode, which honours the
+	// indicator count and the entry map given in the leader.
+	iso2709 bool
+	// copyValues, if true, gives each tag and value its own copy, instead
+	// of a view into the record buffer.
+	copyValues bool
+	// keep, if not nil, decides which fields are decoded. Other fields
+	// are skipped.
+	keep func(tag string) bool
+	// limits bound the resources used for a record.
+	limits Limits
+	// keepRaw, if true, keeps the bytes of the records and fields as read.
+	keepRaw bool
+	// buf, if not nil, is reused for reading the records.
+	buf *[]byte
+}
+
+// readRecord decodes a single record, see readRaw.
+func readRecord(reader io.Reader, opts decodeOptions) (record *Record, err error) {
 	record = &Record{}
-	record.Fields = make([]Field, 0, 8)
+	var body string
+	if record.Leader, body, err = readRaw(reader, opts); err != nil {
+		return
+	}
+	err = record.decode(body, opts)
+	return
+}
 
-	if record.Leader, err = readLeader(reader); err != nil {
+// readRaw reads the leader and the rest of a record in one piece, using the
+// length given in the leader. If the length is not usable, the record is
+// read up to the record terminator instead. This includes the lengths 00000
+// and 99999, which are used for records too long for the leader. The rest of
+// the record is converted to a single string, all tags and values of the
+// decoded record are substrings of it, unless values are copied. An input
+// ending within the record is a Truncated ParseError, an input ending
+// before the record io.EOF.
+func readRaw(reader io.Reader, opts decodeOptions) (leader *Leader, body string, err error) {
+	defer func() { err = readError(err) }()
+	var buf []byte
+	if opts.buf != nil {
+		buf = *opts.buf
+		defer func() { *opts.buf = buf }()
+	}
+	buf = grow(buf, 24)
+	if _, err = io.ReadFull(reader, buf); err != nil {
+		return
+	}
+	if leader, err = parseLeader(buf, opts.iso2709); err != nil {
 		return
 	}
-	dents := make([]*dirent, 0, 8)
+	if opts.keepRaw {
+		leader.setRaw(buf)
+	}
+	max := opts.limits.MaxRecordLength
+	var b []byte
+	if leader.Length >= minRecordLength && leader.Length < maxRecordLength {
+		if max > 0 && leader.Length > max {
+			return nil, "", ErrRecordTooLong
+		}
+		buf = grow(buf, leader.Length)
+		b = buf[24:]
+		if _, err = io.ReadFull(reader, b); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+	} else {
+		if max > 0 {
+			if max -= 24; max <= 0 {
+				return nil, "", ErrRecordTooLong
+			}
+		}
+		if b, err = readUntilTerminator(reader, max); err != nil {
+			return
+		}
+	}
+	return leader, string(b), nil
+}
+
+// grow returns a slice of length n, reusing buf if it is large enough.
+func grow(buf []byte, n int) []byte {
+	if cap(buf) >= n {
+		return buf[:n]
+	}
+	return make([]byte, n)
+}
+
+// readUntilTerminator reads up to and including the next record terminator,
+// but not more than max bytes, if max is positive. Readers of a Reader are
+// scanned in larger chunks, other readers byte by byte.
+func readUntilTerminator(reader io.Reader, max int) (buf []byte, err error) {
+	if cr, ok := reader.(*countingReader); ok {
+		if buf, err = cr.readTerminated(max); err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	b := make([]byte, 1)
 	for {
-		var dent *dirent
-		dent, err = readDirEnt(reader)
-		if err == ErrFieldSeparator {
-			err = nil
-			break
+		if max > 0 && len(buf) >= max {
+			return buf, ErrRecordTooLong
 		}
-		if err != nil {
+		if _, err = io.ReadFull(reader, b); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+		buf = append(buf, b[0])
+		if b[0] == RT {
 			return
 		}
-		dents = append(dents, dent)
 	}
+}
 
+// decode decodes the directory and the fields of a record from the data
+// following the leader. Fields are located by their directory entries, so
+// they may appear in any order and there may be gaps between them. Fields
+// and subfields are allocated in bulk.
+func (record *Record) decode(body string, opts decodeOptions) error {
+	dents, data, indicators, err := parseDirectory(record.Leader, body, opts)
+	if err != nil {
+		return err
+	}
+	fieldError := func(dent dirent, err error) error {
+		pe := newParseError(BadField, err)
+		pe.Tag, pe.Entry, pe.Identifier = dent.tag, dent.entry, identifierOf(dents, data)
+		return pe
+	}
+	var ncontrol, nsubfields int
+	for _, dent := range dents {
+		if opts.isControl(dent.tag) {
+			ncontrol++
+			continue
+		}
+		n := strings.Count(data[dent.startCharPos:dent.startCharPos+dent.length], string(rune(DELIM)))
+		if max := opts.limits.MaxSubFields; max > 0 && n > max {
+			pe := limitError(ErrTooManySubFields)
+			pe.Tag, pe.Entry, pe.Identifier = dent.tag, dent.entry, identifierOf(dents, data)
+			return pe
+		}
+		nsubfields += n
+	}
+	var (
+		controls  = make([]ControlField, 0, ncontrol)
+		datas     = make([]DataField, 0, len(dents)-ncontrol)
+		subfields = make([]SubField, 0, nsubfields)
+		refs      = make([]*SubField, 0, cap(subfields)+cap(datas))
+	)
+	record.Fields = make([]Field, 0, len(dents))
 	for _, dent := range dents {
-		var field Field
-		if strings.HasPrefix(dent.tag, "00") {
-			if field, err = readControl(reader, dent); err != nil {
-				return
+		b := data[dent.startCharPos : dent.startCharPos+dent.length]
+		if opts.isControl(dent.tag) {
+			v, err := decodeControl(b)
+			if err != nil {
+				return fieldError(dent, err)
 			}
-		} else {
-			if field, err = readData(reader, dent); err != nil {
-				return
+			controls = append(controls, ControlField{Tag: dent.tag, Data: v})
+			if opts.keepRaw {
+				controls[len(controls)-1].raw = b
 			}
+			record.Fields = append(record.Fields, &controls[len(controls)-1])
+			continue
+		}
+		datas = append(datas, DataField{Tag: dent.tag})
+		df := &datas[len(datas)-1]
+		if err := df.decode(b, indicators, &subfields, &refs); err != nil {
+			return fieldError(dent, err)
 		}
-		record.Fields = append(record.Fields, field)
+		if opts.keepRaw {
+			df.raw = b
+		}
+		record.Fields = append(record.Fields, df)
 	}
-	rtbuf := make([]byte, 1)
-	if _, err = reader.Read(rtbuf); err != nil {
-		return
+	if opts.keepRaw {
+		record.keepRaw(body, opts)
 	}
-	if rtbuf[0] != RT {
-		err = errors.New("could not read record terminator")
+	if opts.copyValues {
+		record.copyValues()
 	}
-	return
+	return nil
+}
+
+// parseDirectory parses and checks the directory of a record and returns
+// the entries of the fields to decode, the data section and the number of
+// indicators. All errors are a *ParseError.
+func parseDirectory(leader *Leader, body string, opts decodeOptions) (dents []dirent, data string, indicators int, err error) {
+	if len(body) == 0 || body[len(body)-1] != RT {
+		return nil, "", 0, newParseError(MissingTerminator, errors.New("could not read record terminator"))
+	}
+	indicators, length, start, impl := 2, 4, 5, 0
+	if opts.iso2709 {
+		if leader.SubfieldCodeLength != 2 {
+			return nil, "", 0, newParseError(BadLeader, fmt.Errorf("unsupported subfield code length %d", leader.SubfieldCodeLength))
+		}
+		if leader.IndicatorCount > 2 {
+			return nil, "", 0, newParseError(BadLeader, fmt.Errorf("unsupported indicator count %d", leader.IndicatorCount))
+		}
+		indicators, length, start, impl = leader.entryMap()
+	}
+	size := 3 + length + start + impl
+	entries := strings.IndexByte(body, RS)/size + 1
+	if max := opts.limits.MaxDirectoryEntries; max > 0 && entries > max+1 {
+		return nil, "", 0, limitError(ErrTooManyEntries)
+	}
+	dents = make([]dirent, 0, entries)
+	i := 0
+	for body[i] != RS {
+		if i+size >= len(body) {
+			return nil, "", 0, newParseError(BadDirectory, errors.New("directory is not terminated by a field terminator"))
+		}
+		if max := opts.limits.MaxDirectoryEntries; max > 0 && len(dents) == max {
+			return nil, "", 0, limitError(ErrTooManyEntries)
+		}
+		dent, err := parseDirEnt(body[i:i+size], length, start)
+		if err != nil {
+			pe := newParseError(BadDirectory, err)
+			pe.Entry, pe.RawEntry = len(dents), body[i:i+size]
+			return nil, "", 0, pe
+		}
+		dent.entry = len(dents)
+		dents = append(dents, dent)
+		i += size
+	}
+	data = body[i+1 : len(body)-1]
+	if err := checkDirectory(dents, len(data)); err != nil {
+		err.Identifier = identifierOf(dents, data)
+		return nil, "", 0, err
+	}
+	if opts.keep != nil {
+		kept := dents[:0]
+		for _, dent := range dents {
+			if opts.keep(dent.tag) {
+				kept = append(kept, dent)
+			}
+		}
+		dents = kept
+	}
+	return dents, data, indicators, nil
+}
+
+// clone returns a copy of a string, that does not share memory with s.
+func clone(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s)
+	return b.String()
+}
+
+// copyValues replaces all tags and values of a record by copies, so that
+// they no longer keep the record buffer in memory.
+func (record *Record) copyValues() {
+	for _, f := range record.Fields {
+		switch field := f.(type) {
+		case *ControlField:
+			field.Tag, field.Data, field.raw = clone(field.Tag), clone(field.Data), clone(field.raw)
+		case *DataField:
+			field.Tag, field.raw = clone(field.Tag), clone(field.raw)
+			for _, sf := range field.SubFields {
+				sf.Value = clone(sf.Value)
+			}
+		}
+	}
+}
+
+// checkDirectory checks, that all directory entries point into the data
+// section of the given size and that fields do not overlap. Entries sharing
+// exactly the same data are allowed.
+func checkDirectory(dents []dirent, size int) *ParseError {
+	invalid := func(dent dirent, format string, a ...interface{}) *ParseError {
+		pe := newParseError(BadDirectory, fmt.Errorf(format, a...))
+		pe.Tag, pe.Entry = dent.tag, dent.entry
+		return pe
+	}
+	sorted := true
+	for i, dent := range dents {
+		if dent.length < 1 || dent.startCharPos < 0 {
+			return invalid(dent, "invalid length %d or start %d", dent.length, dent.startCharPos)
+		}
+		if dent.startCharPos+dent.length > size {
+			return invalid(dent, "out of bounds: field ends at %d, data has %d bytes",
+				dent.startCharPos+dent.length, size)
+		}
+		if i > 0 && dent.startCharPos < dents[i-1].startCharPos {
+			sorted = false
+		}
+	}
+	if !sorted {
+		dents = append([]dirent(nil), dents...)
+		sort.Slice(dents, func(i, j int) bool {
+			return dents[i].startCharPos < dents[j].startCharPos
+		})
+	}
+	for i := 1; i < len(dents); i++ {
+		prev, cur := dents[i-1], dents[i]
+		if prev.startCharPos == cur.startCharPos && prev.length == cur.length {
+			continue
+		}
+		if prev.startCharPos+prev.length > cur.startCharPos {
+			return invalid(cur, "fields %s (%d-%d) and %s (%d-%d) overlap",
+				prev.tag, prev.startCharPos, prev.startCharPos+prev.length-1,
+				cur.tag, cur.startCharPos, cur.startCharPos+cur.length-1)
+		}
+	}
+	return nil
 }
 
 // Identifier returns the record identifier or an empty string.
@@ -102,6 +387,82 @@ func (record *Record) AddField(f Field) {
 	record.Fields = append(record.Fields, f)
 }
 
+// InsertFieldSorted inserts a field before the first field with a greater
+// tag, so that the fields of a record in tag order stay in order. Fields with
+// the same tag keep the order in which they were added.
+func (record *Record) InsertFieldSorted(f Field) {
+	i := len(record.Fields)
+	for j, field := range record.Fields {
+		if field.GetTag() > f.GetTag() {
+			i = j
+			break
+		}
+	}
+	record.Fields = append(record.Fields, nil)
+	copy(record.Fields[i+1:], record.Fields[i:])
+	record.Fields[i] = f
+}
+
+// RemoveFields removes all fields with the given tag and returns them.
+func (record *Record) RemoveFields(tag string) (removed []Field) {
+	kept := make([]Field, 0, len(record.Fields))
+	for _, field := range record.Fields {
+		if field.GetTag() == tag {
+			removed = append(removed, field)
+		} else {
+			kept = append(kept, field)
+		}
+	}
+	record.Fields = kept
+	return
+}
+
+// ReplaceField replaces the field old, which is compared by identity, with
+// f. It returns false, if old is not a field of the record.
+func (record *Record) ReplaceField(old, f Field) bool {
+	for i, field := range record.Fields {
+		if field == old {
+			record.Fields[i] = f
+			return true
+		}
+	}
+	return false
+}
+
+// SortFields sorts the fields by tag. Fields with the same tag keep their
+// order.
+func (record *Record) SortFields() {
+	sort.SliceStable(record.Fields, func(i, j int) bool {
+		return record.Fields[i].GetTag() < record.Fields[j].GetTag()
+	})
+}
+
+// Clone returns a deep copy of the record, which can be changed without
+// changing the record. A copy of a record read with KeepRaw keeps its raw
+// bytes.
+func (record *Record) Clone() *Record {
+	clone := &Record{raw: record.raw, dirty: record.dirty}
+	if record.Leader != nil {
+		leader := *record.Leader
+		clone.Leader = &leader
+	}
+	if record.Fields != nil {
+		clone.Fields = make([]Field, len(record.Fields))
+	}
+	clones := make(map[Field]Field, len(record.Fields))
+	for i, f := range record.Fields {
+		clone.Fields[i] = cloneField(f)
+		clones[f] = clone.Fields[i]
+	}
+	if record.fields != nil {
+		clone.fields = make([]Field, len(record.fields))
+		for i, f := range record.fields {
+			clone.fields[i] = clones[f]
+		}
+	}
+	return clone
+}
+
 // String returns the Record as a string.
 func (record *Record) String() string {
 	estrings := make([]string, len(record.Fields))
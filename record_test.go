@@ -1,6 +1,11 @@
 package marc21
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
 
 // TestAddField tests adding of a single field.
 func TestAddField(t *testing.T) {
@@ -17,3 +22,160 @@ func TestAddField(t *testing.T) {
 		t.Errorf("field.Data, got %v, want %v", data, "12345")
 	}
 }
+
+// buildRecord assembles a binary record from a directory and field data.
+func buildRecord(dir, data string) []byte {
+	dir += "\x1e"
+	data += "\x1d"
+	leader := Leader{
+		Length:             24 + len(dir) + len(data),
+		Status:             'n',
+		Type:               'a',
+		CharacterEncoding:  'a',
+		BaseAddress:        24 + len(dir),
+		IndicatorCount:     2,
+		SubfieldCodeLength: 2,
+		LengthOfLength:     4,
+		LengthOfStartPos:   5,
+	}
+	return append(leader.Bytes(), dir+data...)
+}
+
+func TestReadRecordDirectoryOrder(t *testing.T) {
+	b := buildRecord("245001000006001000600000", "12345\x1e10\x1faTitle\x1e")
+	record, err := ReadRecord(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Fields) != 2 {
+		t.Fatalf("len(record.Fields), got %v, want %v", len(record.Fields), 2)
+	}
+	if record.Fields[0].GetTag() != "245" {
+		t.Errorf("GetTag, got %v, want %v", record.Fields[0].GetTag(), "245")
+	}
+	if record.Identifier() != "12345" {
+		t.Errorf("Identifier, got %v, want %v", record.Identifier(), "12345")
+	}
+	if v := record.GetSubFields("245", 'a')[0].Value; v != "Title" {
+		t.Errorf("GetSubFields, got %v, want %v", v, "Title")
+	}
+}
+
+func TestReadRecordDirectoryGaps(t *testing.T) {
+	b := buildRecord("001000600003", "xyz12345\x1e")
+	record, err := ReadRecord(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Identifier() != "12345" {
+		t.Errorf("Identifier, got %v, want %v", record.Identifier(), "12345")
+	}
+}
+
+func TestReadRecordDirectoryErrors(t *testing.T) {
+	var cases = []struct {
+		about string
+		dir   string
+		data  string
+	}{
+		{"out of bounds", "001000700000", "12345\x1e"},
+		{"overlap", "001000600000245001000003", "12345\x1e10\x1faTitle\x1e"},
+		{"missing field terminator", "001000600000", "123456"},
+		{"invalid length", "0010x0600000", "12345\x1e"},
+	}
+	for _, c := range cases {
+		_, err := ReadRecord(bytes.NewReader(buildRecord(c.dir, c.data)))
+		if err == nil {
+			t.Errorf("%s: got nil, want some error", c.about)
+		}
+	}
+}
+
+func TestReadRecordOversize(t *testing.T) {
+	var dir, data string
+	for i := 0; i < 20; i++ {
+		dir += fmt.Sprintf("500%04d%05d", 5000, len(data))
+		data += "  \x1fa" + strings.Repeat("x", 4995) + "\x1e"
+	}
+	b := buildRecord(dir, data)
+	if len(b) <= maxRecordLength || string(b[0:5]) != "99999" {
+		t.Fatalf("record of %d bytes with length %q, want more than %d and 99999", len(b), b[0:5], maxRecordLength)
+	}
+	for _, length := range []string{"99999", "00000"} {
+		copy(b[0:5], length)
+		stream := append(append([]byte{}, b...), b...)
+		r := NewReader(bytes.NewReader(stream))
+		for r.Scan() {
+			if n := len(r.Record().GetFields("500")); n != 20 {
+				t.Errorf("length %s, 500, got %d fields, want 20", length, n)
+			}
+		}
+		if r.Err() != nil {
+			t.Fatalf("length %s: %s", length, r.Err())
+		}
+		if r.Count() != 2 {
+			t.Errorf("length %s, Count, got %d, want 2", length, r.Count())
+		}
+		if _, err := ReadRecord(bytes.NewReader(b)); err != nil {
+			t.Errorf("length %s, ReadRecord: %s", length, err)
+		}
+	}
+}
+
+func TestRecordMutation(t *testing.T) {
+	tags := func(record *Record) string {
+		var s []string
+		for _, f := range record.Fields {
+			s = append(s, f.GetTag())
+		}
+		return strings.Join(s, " ")
+	}
+	record := &Record{}
+	for _, tag := range []string{"245", "001", "650", "100", "650", "008"} {
+		record.InsertFieldSorted(&DataField{Tag: tag})
+	}
+	if got, want := tags(record), "001 008 100 245 650 650"; got != want {
+		t.Errorf("InsertFieldSorted, got %v, want %v", got, want)
+	}
+	second := record.Fields[5]
+	if removed := record.RemoveFields("650"); len(removed) != 2 || removed[1] != second {
+		t.Errorf("RemoveFields, got %v, want both 650 fields", removed)
+	}
+	if got, want := tags(record), "001 008 100 245"; got != want {
+		t.Errorf("RemoveFields, got %v, want %v", got, want)
+	}
+	old := record.Fields[2]
+	if !record.ReplaceField(old, &DataField{Tag: "110"}) {
+		t.Errorf("ReplaceField, got false, want true")
+	}
+	if record.ReplaceField(old, &DataField{Tag: "110"}) {
+		t.Errorf("ReplaceField of removed field, got true, want false")
+	}
+	record.AddField(&DataField{Tag: "020"})
+	record.SortFields()
+	if got, want := tags(record), "001 008 020 110 245"; got != want {
+		t.Errorf("SortFields, got %v, want %v", got, want)
+	}
+}
+
+func TestRecordClone(t *testing.T) {
+	r := NewReader(bytes.NewReader(buildRecord("001000600000245001500006", "12345\x1e10\x1faTitle\x1fbSub\x1e")))
+	r.KeepRaw = true
+	record, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := record.Clone()
+	if clone.Dirty() || clone.String() != record.String() {
+		t.Errorf("Clone, got %v and dirty %v, want %v", clone, clone.Dirty(), record)
+	}
+	clone.Leader.Status = 'd'
+	clone.Fields[0].(*ControlField).Data = "1"
+	clone.GetFields("245")[0].(*DataField).SetSubField('a', "Other")
+	if record.Leader.Status != 'n' || record.Identifier() != "12345" || record.GetSubFields("245", 'a')[0].Value != "Title" {
+		t.Errorf("changing the clone changed the record: %v", record)
+	}
+	if !clone.Dirty() || record.Dirty() {
+		t.Errorf("Dirty, got %v for the clone and %v for the record, want true and false", clone.Dirty(), record.Dirty())
+	}
+}
@@ -0,0 +1,249 @@
+package schema
+
+// bibliographic is the MARC 21 Format for Bibliographic Data, following
+// https://www.loc.gov/marc/bibliographic/. Obsolete fields and subfields are
+// not included. Each line defines a field with the columns:
+//
+//	tag, R or NR (repeatable), first and second indicator values, subfield
+//	codes, repeatable subfield codes, name
+//
+// In indicator values, "#" is a blank. An empty column is written as "-",
+// as are the indicators and subfields of control fields; their length, if
+// fixed, follows the name in brackets. The alternate graphic representation
+// (880) and the foreign MARC information field (886) may carry any
+// indicators and subfields, which is written as "*".
+const bibliographic = `
+001 NR - - - - Control Number
+003 NR - - - - Control Number Identifier
+005 NR - - - - Date and Time of Latest Transaction [16]
+006 R - - - - Fixed-Length Data Elements-Additional Material Characteristics [18]
+007 R - - - - Physical Description Fixed Field
+008 NR - - - - Fixed-Length Data Elements-General Information [40]
+010 NR # # abz8 bz8 Library of Congress Control Number
+013 R # # abcdef68 def8 Patent Control Information
+015 R # # aqz268 aqz8 National Bibliography Number
+016 R #7 # az28 z8 National Bibliographic Agency Control Number
+017 R # #8 abdiz268 az8 Copyright or Legal Deposit Number
+018 NR # # a68 8 Copyright Article-Fee Code
+020 R # # acqz68 qz8 International Standard Book Number
+022 R #01 # almyz01268 myz018 International Standard Serial Number
+024 R 0123478 #01 acdqz268 qz8 Other Standard Identifier
+025 R # # a8 a8 Overseas Acquisition Number
+026 R # # abcde2568 a58 Fingerprint Identifier
+027 R # # aqz68 qz8 Standard Technical Report Number
+028 R 0123456 0123 abq68 q8 Publisher or Distributor Number
+030 R # # az68 z8 CODEN Designation
+031 R # # abcdegmnopqrstuyz268 eqnsuz8 Musical Incipits Information
+032 R # # ab68 8 Postal Registration Number
+033 R #012 #012 abcp012368 abcp0128 Date/Time and Place of an Event
+034 R 013 #01 abcdefghjkmnprstxyz012368 bchjkmnprstxyz018 Coded Cartographic Mathematical Data
+035 R # # az68 z8 System Control Number
+036 NR # # ab68 8 Original Study Number for Computer Data Files
+037 R #23 # abcfgn3568 cfgn58 Source of Acquisition
+038 NR # # a68 8 Record Content Licensor
+040 NR # # abcde68 de8 Cataloging Source
+041 R #01 #7 abdefghijkmnpqrt268 abdefghijkmnpqrt8 Language Code
+042 NR # # a a Authentication Code
+043 NR # # abc01268 abc0128 Geographic Area Code
+044 NR # # abc268 abc28 Country of Publishing/Producing Entity Code
+045 NR #012 # abc68 abc8 Time Period of Content
+046 R #123 # abcdejklmnop268 8 Special Coded Dates
+047 R #7 # a28 a8 Form of Musical Composition Code
+048 R #7 # ab28 ab8 Number of Musical Instruments or Voices Codes
+050 R #01 04 ab01368 a018 Library of Congress Call Number
+051 R # # abc8 8 Library of Congress Copy, Issue, Offprint Statement
+052 R #017 # abd268 bd8 Geographic Classification
+055 R #01 0123456789 ab01268 018 Classification Numbers Assigned in Canada
+060 R #01 04 ab018 a018 National Library of Medicine Call Number
+061 R # # abc8 a8 National Library of Medicine Copy Statement
+066 NR # # abc c Character Sets Present
+070 R 01 # ab018 a018 National Agricultural Library Call Number
+071 R # # abc8 c8 National Agricultural Library Copy Statement
+072 R # 07 ax268 x8 Subject Category Code
+074 R # # az8 z8 GPO Item Number
+080 R #01 # abx01268 x018 Universal Decimal Classification Number
+082 R 017 #04 abmq01268 a018 Dewey Decimal Classification Number
+083 R 017 #47 acmqyz01268 acyz018 Additional Dewey Decimal Classification Number
+084 R # # abq01268 a018 Other Classification Number
+085 R # # abcfstuvwyz0168 abcfstuvwyz018 Synthesized Classification Number Components
+086 R #01 # az01268 z018 Government Document Classification Number
+088 R # # az68 z8 Report Number
+100 NR 013 # abcdefgjklnpqtu012468 cejknp0148 Main Entry-Personal Name
+110 NR 012 # abcdefgklnptu012468 bdeknp0148 Main Entry-Corporate Name
+111 NR 012 # acdefgjklnpqtu012468 ejknp0148 Main Entry-Meeting Name
+130 NR 0123456789 # adfghklmnoprst01268 dkmnpt018 Main Entry-Uniform Title
+210 R 01 #0 ab268 8 Abbreviated Title
+222 R # 0123456789 ab68 8 Key Title
+240 NR 01 0123456789 adfghklmnoprs01268 dkmnp018 Uniform Title
+242 R 01 0123456789 abchnpy68 np8 Translation of Title by Cataloging Agency
+243 NR 01 0123456789 adfghklmnoprs68 dkmnp8 Collective Uniform Title
+245 NR 01 0123456789 abcfghknps678 knp8 Title Statement
+246 R 0123 #012345678 abfghinp568 np58 Varying Form of Title
+247 R 01 01 abfghnpx68 np8 Former Title
+250 R #23 #01 ab368 8 Edition Statement
+251 R # # a012368 a018 Version Information
+254 NR # # a68 8 Musical Presentation Statement
+255 R # # abcdefg68 8 Cartographic Mathematical Data
+256 NR # # a68 8 Computer File Characteristics
+257 R # # a01268 a018 Country of Producing Entity
+258 R # # ab68 8 Philatelic Issue Data
+260 R #23 #01 abcefg368 abcefg8 Publication, Distribution, etc. (Imprint)
+263 NR # # a68 8 Projected Publication Date
+264 R #23 01234 abc368 abc8 Production, Publication, Distribution, Manufacture, and Copyright Notice
+270 R #12 #07 abcdefghijklmnpqrz468 ajklmprz48 Address
+300 R # # abcefg368 acfg8 Physical Description
+306 NR # # a68 a8 Playing Time
+307 R #8 # ab68 8 Hours, etc.
+310 NR # # ab0168 018 Current Publication Frequency
+321 R # # ab0168 018 Former Publication Frequency
+334 R # # ab012368 ab018 Mode of Issuance
+335 R # # ab012368 ab018 Extension Plan
+336 R # # ab012368 ab018 Content Type
+337 R # # ab012368 ab018 Media Type
+338 R # # ab012368 ab018 Carrier Type
+340 R # # abcdefghijkmnopq012368 abcdefghijkmnopq018 Physical Medium
+341 R #01 # abcde268 bcde8 Accessibility Content
+342 R 01 012345678 abcdefghijklmnopqrstuvw268 8 Geospatial Reference Data
+343 R # # abcdefghi68 8 Planar Coordinate Data
+344 R # # abcdefghi012368 abcdefghi018 Sound Characteristics
+345 R # # abcd012368 abcd018 Moving Image Characteristics
+346 R # # ab012368 ab018 Video Characteristics
+347 R # # abcdef012368 abcdef018 Digital File Characteristics
+348 R # # abcd012368 abcd018 Notated Music Characteristics
+351 R # # abc368 ab8 Organization and Arrangement of Materials
+352 R # # abcdefgiq68 b8 Digital Graphic Representation
+355 R 0123458 # abcdefghj68 b8 Security Classification Control
+357 NR # # abcg68 bcg8 Originator Dissemination Control
+362 R 01 # az68 8 Dates of Publication and/or Sequential Designation
+363 R #01 #01 abcdefghijklmuvxz68 xz8 Normalized Date and Sequential Designation
+365 R # # abcdefghijkm268 8 Trade Price
+366 R # # abcdefgjkm268 8 Trade Availability Information
+370 R # # cfgistuv0123468 cfgiuv0148 Associated Place
+377 R # #7 al012368 al018 Associated Language
+380 R # # a012368 a018 Form of Work
+381 R # # auv012368 auv018 Other Distinguishing Characteristics of Work or Expression
+382 R #0123 #01 abdenprstv012368 abdenpv018 Medium of Performance
+383 R # # abcde2368 abcd8 Numeric Designation of Musical Work
+384 R #01 # a368 8 Key
+385 R # # abmn012368 ab018 Audience Characteristics
+386 R # # abimn0123468 abi0148 Creator/Contributor Characteristics
+388 R #12 # a012368 a018 Time Period of Creation
+490 R 01 # alvxyz3678 avxyz8 Series Statement
+500 R # # a35678 8 General Note
+501 R # # a568 8 With Note
+502 R # # abcdgo678 go8 Dissertation Note
+504 R # # ab678 8 Bibliography, Etc. Note
+505 R 0128 #0 agrtu678 grtu8 Formatted Contents Note
+506 R #01 # abcdefgqu235678 bcdefu8 Restrictions on Access Note
+507 R # # ab68 8 Scale Note for Visual Materials
+508 R # # a678 8 Creation/Production Credits Note
+510 R 01234 # abcux368 u8 Citation/References Note
+511 R 01 # a678 8 Participant or Performer Note
+513 R # # ab68 8 Type of Report and Period Covered Note
+514 NR # # abcdefghijkmuz68 bcdfghijkuz8 Data Quality Note
+515 R # # a68 8 Numbering Peculiarities Note
+516 R #8 # a68 8 Type of Computer File or Data Note
+518 R # # adop012368 dop018 Date/Time and Place of an Event Note
+520 R #012348 # abcu23678 cu8 Summary, Etc.
+521 R #012348 # ab368 a8 Target Audience Note
+522 R #8 # a68 8 Geographic Coverage Note
+524 R #8 # a2368 8 Preferred Citation of Described Materials Note
+525 R # # a68 8 Supplement Note
+526 R 08 # abcdixz568 dxz8 Study Program Information Note
+530 R # # abcdu368 u8 Additional Physical Form Available Note
+532 R 0128 # a68 8 Accessibility Note
+533 R # # abcdefmny35678 bcfmn8 Reproduction Note
+534 R # # abcefklmnoptxz368 fknoxz8 Original Version Note
+535 R 12 # abcdg368 bc8 Location of Originals/Duplicates Note
+536 R # # abcdefgh68 bcdefgh8 Funding Information Note
+538 R # # aiu35678 u8 System Details Note
+540 R # # abcdfgqu23568 fgu8 Terms Governing Use and Reproduction Note
+541 R #01 # abcdefhno3568 hno8 Immediate Source of Acquisition Note
+542 R #01 # abcdefghijklmnopqrsu368 defhklnopsu8 Information Relating to Copyright Status
+544 R #01 # abcden368 abcden8 Location of Other Archival Materials Note
+545 R #01 # abu68 u8 Biographical or Historical Data
+546 R # # ab368 b8 Language Note
+547 R # # a68 8 Former Title Complexity Note
+550 R # # a68 8 Issuing Body Note
+552 R # # abcdefghijklmnopuz68 mnouz8 Entity and Attribute Information Note
+555 R #08 # abcdu368 bu8 Cumulative Index/Finding Aids Note
+556 R #8 # az68 z8 Information About Documentation Note
+561 R #01 # au3568 u8 Ownership and Custodial History
+562 R # # abcde3568 abcde8 Copy and Version Identification Note
+563 R # # au3568 u8 Binding Information
+565 R #08 # abcde368 bc8 Case File Characteristics Note
+567 R #8 # ab01268 b018 Methodology Note
+580 R # # a68 8 Linking Entry Complexity Note
+581 R #8 # az368 z8 Publications About Described Materials Note
+583 R #01 # abcdefhijklnouxz23568 bcdefhijklnouxz8 Action Note
+584 R # # ab3568 ab8 Accumulation and Frequency of Use Note
+585 R # # a3568 8 Exhibitions Note
+586 R #8 # a368 8 Awards Note
+588 R #01 # a568 8 Source of Description Note
+600 R 013 01234567 abcdefghjklmnopqrstuvxyz0123468 cejkmnpvxyz0148 Subject Added Entry-Personal Name
+610 R 012 01234567 abcdefghklmnoprstuvxyz0123468 bdekmnpvxyz0148 Subject Added Entry-Corporate Name
+611 R 012 01234567 acdefghjklnpqstuvxyz0123468 ejknpvxyz0148 Subject Added Entry-Meeting Name
+630 R 0123456789 01234567 adefghklmnoprstvxyz0123468 dekmnptvxyz0148 Subject Added Entry-Uniform Title
+647 R # 01234567 acdgvxyz012368 cgvxyz018 Subject Added Entry-Named Event
+648 R # 01234567 avxyz012368 vxyz018 Subject Added Entry-Chronological Term
+650 R #012 01234567 abcdegvxyz0123468 begvxyz0148 Subject Added Entry-Topical Term
+651 R # 01234567 aegvxyz0123468 egvxyz0148 Subject Added Entry-Geographic Name
+653 R #012 #0123456 a68 a8 Index Term-Uncontrolled
+654 R #012 # abcevyz0123468 bevyz0148 Subject Added Entry-Faceted Topical Terms
+655 R #0 01234567 abcvxyz0123568 bcvxyz0158 Index Term-Genre/Form
+656 R # 7 akvxyz012368 vxyz018 Index Term-Occupation
+657 R # 7 avxyz012368 vxyz018 Index Term-Function
+658 R # # abcd268 b8 Index Term-Curriculum Objective
+662 R # # abcdefgh012468 befg0148 Subject Added Entry-Hierarchical Place Name
+688 R # # aeg0123468 eg0148 Subject Added Entry-Type of Entity Unspecified
+700 R 013 #2 abcdefghijklmnopqrstux01234568 ceijkmnp01458 Added Entry-Personal Name
+710 R 012 #2 abcdefghiklmnoprstux01234568 bdeikmnp01458 Added Entry-Corporate Name
+711 R 012 #2 acdefghijklnpqstux01234568 eijknp01458 Added Entry-Meeting Name
+720 R #12 # ae468 e48 Added Entry-Uncontrolled Name
+730 R 0123456789 #2 adfghiklmnoprstx01234568 dikmnpt01458 Added Entry-Uniform Title
+740 R 0123456789 #2 ahnp568 np58 Added Entry-Uncontrolled Related/Analytical Title
+751 R # # ae0123468 e0148 Added Entry-Geographic Name
+752 R # # abcdefgh012468 befg0148 Added Entry-Hierarchical Place Name
+753 R # # abc01268 018 System Details Access to Computer Files
+754 R # # acdxz01268 cdxz018 Added Entry-Taxonomic Identification
+758 R # # ai0134568 i01458 Resource Identifier
+760 R 01 #8 abcdghimnostwxy4678 ginow48 Main Series Entry
+762 R 01 #8 abcdghimnostwxy4678 ginow48 Subseries Entry
+765 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Original Language Entry
+767 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Translation Entry
+770 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Supplement/Special Issue Entry
+772 R 01 #08 abcdghikmnorstuwxyz4678 giknorwz48 Supplement Parent Entry
+773 R 01 #8 abdghikmnopqrstuwxyz34678 giknorwz48 Host Item Entry
+774 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Constituent Unit Entry
+775 R 01 #8 abcdefghikmnorstuwxyz4678 giknorwz48 Other Edition Entry
+776 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Additional Physical Form Entry
+777 R 01 #8 abcdghikmnostwxy4678 giknow48 Issued With Entry
+780 R 01 01234567 abcdghikmnorstuwxyz4678 giknorwz48 Preceding Entry
+785 R 01 012345678 abcdghikmnorstuwxyz4678 giknorwz48 Succeeding Entry
+786 R 01 #8 abcdghijkmnoprstuvwxyz4678 giknorwz48 Data Source Entry
+787 R 01 #8 abcdghikmnorstuwxyz4678 giknorwz48 Other Relationship Entry
+800 R 013 # abcdefghjklmnopqrstuvwx012345678 cejkmnpw01458 Series Added Entry-Personal Name
+810 R 012 # abcdefghklmnoprstuvwx012345678 bdekmnpw01458 Series Added Entry-Corporate Name
+811 R 012 # acdefghjklnpqstuvwx012345678 ejknpw01458 Series Added Entry-Meeting Name
+830 R # 0123456789 adfghklmnoprstvwx01235678 dkmnpw0158 Series Added Entry-Uniform Title
+841 NR - - - - Holdings Coded Data Values
+842 NR # # a68 8 Textual Physical Form Designator
+843 R # # abcdefmn3578 fmn8 Reproduction Note
+844 NR # # a8 8 Name of Unit
+845 R # # abcdfgqu23568 fgu8 Terms Governing Use and Reproduction Note
+850 R # # a8 a8 Holding Institution
+852 R #012345678 #012 abcdefghijklmnpqstuxz23568 bdefgikmsuxz8 Location
+853 R 0123 0123 abcdefghijklmnopqtuvwxyz2368 8 Captions and Pattern-Basic Bibliographic Unit
+856 R #01234 #01278 abcdfhijklmnopqrstuvwxyz23678 abfimstuwxyz8 Electronic Location and Access
+863 R #345 #01234 abcdefghijklmnopqstvwxz68 qstvxz8 Enumeration and Chronology-Basic Bibliographic Unit
+866 R #345 0127 axz68 xz8 Textual Holdings-Basic Bibliographic Unit
+876 R # # abcdehjlprtxz3468 bcdehjlrxz8 Item Information-Basic Bibliographic Unit
+880 R * * * * Alternate Graphic Representation
+881 R # # abcdefghijnopqrstuvwxyz368 8 Manifestation Statements
+882 NR # # aiw68 aw8 Replacement Record Information
+883 R #01 # acdquwx018 uwx018 Metadata Provenance
+884 R # # agkmq m Description Conversion Information
+885 R # # abcdwx0125 wx01 Matching Information
+886 R 012 # * * Foreign MARC Information Field
+887 R # # a2 - Non-MARC Information Field
+`
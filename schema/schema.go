@@ -0,0 +1,380 @@
+// Package schema validates records against a MARC 21 format definition.
+//
+// The definition of the bibliographic format, Bibliographic, is built in.
+// It lists the tags, indicators and subfield codes of all current fields
+// and whether they are repeatable, the values of the leader positions, that
+// are checked, and the mandatory fields 008 and 245.
+//
+//	for _, issue := range schema.Validate(record) {
+//	    fmt.Println(issue)
+//	}
+//
+// Validate returns the issues found in record order, after the issues of
+// the leader; missing mandatory fields are reported last. Local fields (9XX
+// and X9X), that are not part of the definition, are not checked.
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miku/marc21"
+)
+
+// Severity tells, whether an issue violates the format or is merely
+// suspicious.
+type Severity int
+
+const (
+	// Warning is an issue, that does not make the record invalid, like an
+	// undefined tag or an empty subfield.
+	Warning Severity = iota + 1
+	// Error is a violation of the format.
+	Error
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Kind classifies issues.
+type Kind int
+
+const (
+	// InvalidLeader is an undefined value of a leader position or a missing
+	// leader.
+	InvalidLeader Kind = iota + 1
+	// MissingField is a mandatory field, that the record lacks.
+	MissingField
+	// UnknownField is a field, that is not defined by the format.
+	UnknownField
+	// NonRepeatableField is a second or later occurrence of a field, that
+	// is not repeatable.
+	NonRepeatableField
+	// InvalidControlField is a control field of the wrong length, or a
+	// field, that is a control field in the record but not in the format,
+	// or vice versa.
+	InvalidControlField
+	// InvalidIndicator is an undefined indicator value.
+	InvalidIndicator
+	// MissingSubField is a data field without subfields.
+	MissingSubField
+	// UnknownSubField is a subfield code, that is not defined for the
+	// field.
+	UnknownSubField
+	// NonRepeatableSubField is a second or later occurrence of a subfield,
+	// that is not repeatable.
+	NonRepeatableSubField
+	// EmptySubField is a subfield without value.
+	EmptySubField
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case InvalidLeader:
+		return "invalid leader"
+	case MissingField:
+		return "missing field"
+	case UnknownField:
+		return "unknown field"
+	case NonRepeatableField:
+		return "non-repeatable field"
+	case InvalidControlField:
+		return "invalid control field"
+	case InvalidIndicator:
+		return "invalid indicator"
+	case MissingSubField:
+		return "missing subfield"
+	case UnknownSubField:
+		return "unknown subfield"
+	case NonRepeatableSubField:
+		return "non-repeatable subfield"
+	case EmptySubField:
+		return "empty subfield"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// leaderTag is the tag of issues of the leader.
+const leaderTag = "LDR"
+
+// Issue is a problem found in a record.
+type Issue struct {
+	Severity Severity
+	Kind     Kind
+	// Tag is the tag of the field involved, or "LDR" for the leader.
+	Tag string
+	// Field is the zero based index of the field in the fields of the
+	// record, or -1 for the leader and missing fields.
+	Field int
+	// Position is the leader position or the indicator (1 or 2) involved,
+	// or -1.
+	Position int
+	// Code is the subfield code involved, if any.
+	Code byte
+	// Message describes the issue.
+	Message string
+}
+
+// String returns the severity and message of the issue.
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s: %s", issue.Severity, issue.Message)
+}
+
+// FieldDefinition defines a field of a format.
+type FieldDefinition struct {
+	Tag, Name  string
+	Repeatable bool
+	// Control is true for control fields, which have no indicators and
+	// subfields.
+	Control bool
+	// Length is the length of a fixed-length control field, or 0.
+	Length int
+	// Ind1 and Ind2 contain the allowed indicator values, with a blank as
+	// ' '. An empty string allows any value.
+	Ind1, Ind2 string
+	// SubFields maps the defined subfield codes to whether they are
+	// repeatable. A nil map allows any subfield.
+	SubFields map[byte]bool
+}
+
+// Format is the definition of a MARC 21 format.
+type Format struct {
+	// Fields maps tags to field definitions.
+	Fields map[string]*FieldDefinition
+	// Leader maps leader positions to their allowed values. Positions not
+	// contained are not checked.
+	Leader map[int]string
+	// Required lists the tags of mandatory fields.
+	Required []string
+	// IsLocal decides, whether an undefined tag denotes a local field,
+	// which is not checked. If nil, all undefined tags are reported.
+	IsLocal func(tag string) bool
+}
+
+// Bibliographic is the MARC 21 Format for Bibliographic Data.
+var Bibliographic = &Format{
+	Fields: parseFields(bibliographic),
+	Leader: map[int]string{
+		5:  "acdnp",
+		6:  "acdefgijkmoprt",
+		7:  "abcdims",
+		8:  " a",
+		9:  " a",
+		10: "2",
+		11: "2",
+		17: " 123457uz8",
+		18: " acinu",
+		19: " abc",
+		20: "4",
+		21: "5",
+		22: "0",
+		23: "0",
+	},
+	Required: []string{"008", "245"},
+	IsLocal:  IsLocalTag,
+}
+
+// IsLocalTag is true for the tags, that MARC 21 reserves for local use:
+// 9XX and X9X, like 590 or 090.
+func IsLocalTag(tag string) bool {
+	return len(tag) == 3 && (tag[0] == '9' || tag[1] == '9')
+}
+
+// Validate checks a record against the bibliographic format.
+func Validate(record *marc21.Record) []Issue {
+	return Bibliographic.Validate(record)
+}
+
+// Validate checks a record against the format and returns the issues found,
+// or nil, if the record is valid.
+func (format *Format) Validate(record *marc21.Record) []Issue {
+	v := &validator{format: format}
+	v.leader(record.Leader)
+	seen := make(map[string]int)
+	for i, f := range record.Fields {
+		tag := f.GetTag()
+		def := format.Fields[tag]
+		if def == nil {
+			if format.IsLocal == nil || !format.IsLocal(tag) {
+				v.add(Warning, UnknownField, tag, i, -1, 0, "%s: undefined field", tag)
+			}
+			continue
+		}
+		if seen[tag]++; seen[tag] > 1 && !def.Repeatable {
+			v.add(Error, NonRepeatableField, tag, i, -1, 0, "%s: field is not repeatable", tag)
+		}
+		switch field := f.(type) {
+		case *marc21.ControlField:
+			v.controlField(def, i, field)
+		case *marc21.DataField:
+			v.dataField(def, i, field)
+		}
+	}
+	for _, tag := range format.Required {
+		if seen[tag] == 0 {
+			v.add(Error, MissingField, tag, -1, -1, 0, "%s: mandatory field missing", tag)
+		}
+	}
+	return v.issues
+}
+
+// validator collects the issues of a record.
+type validator struct {
+	format *Format
+	issues []Issue
+}
+
+// add appends an issue.
+func (v *validator) add(severity Severity, kind Kind, tag string, field, pos int, code byte, format string, a ...interface{}) {
+	v.issues = append(v.issues, Issue{
+		Severity: severity,
+		Kind:     kind,
+		Tag:      tag,
+		Field:    field,
+		Position: pos,
+		Code:     code,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// leader checks the leader positions defined by the format.
+func (v *validator) leader(leader *marc21.Leader) {
+	if leader == nil {
+		v.add(Error, InvalidLeader, leaderTag, -1, -1, 0, "leader missing")
+		return
+	}
+	b := leader.Bytes()
+	for pos := range b {
+		values, ok := v.format.Leader[pos]
+		if ok && strings.IndexByte(values, b[pos]) < 0 {
+			v.add(Error, InvalidLeader, leaderTag, -1, pos, 0, "leader/%02d: undefined value %q", pos, b[pos])
+		}
+	}
+}
+
+// controlField checks a control field.
+func (v *validator) controlField(def *FieldDefinition, i int, cf *marc21.ControlField) {
+	switch {
+	case !def.Control:
+		v.add(Error, InvalidControlField, cf.Tag, i, -1, 0, "%s: control field, want data field", cf.Tag)
+	case def.Length > 0 && len(cf.Data) != def.Length:
+		v.add(Error, InvalidControlField, cf.Tag, i, -1, 0, "%s: length %d, want %d", cf.Tag, len(cf.Data), def.Length)
+	}
+}
+
+// dataField checks the indicators and subfields of a data field.
+func (v *validator) dataField(def *FieldDefinition, i int, df *marc21.DataField) {
+	if def.Control {
+		v.add(Error, InvalidControlField, df.Tag, i, -1, 0, "%s: data field, want control field", df.Tag)
+		return
+	}
+	for n, ind := range []struct {
+		value   byte
+		allowed string
+	}{{df.Ind1, def.Ind1}, {df.Ind2, def.Ind2}} {
+		if ind.allowed != "" && strings.IndexByte(ind.allowed, ind.value) < 0 {
+			v.add(Error, InvalidIndicator, df.Tag, i, n+1, 0, "%s: undefined indicator %d %q", df.Tag, n+1, ind.value)
+		}
+	}
+	if len(df.SubFields) == 0 {
+		v.add(Error, MissingSubField, df.Tag, i, -1, 0, "%s: no subfields", df.Tag)
+		return
+	}
+	seen := make(map[byte]bool)
+	for _, sf := range df.SubFields {
+		if sf == nil {
+			continue
+		}
+		if def.SubFields != nil {
+			repeatable, ok := def.SubFields[sf.Code]
+			switch {
+			case !ok:
+				v.add(Error, UnknownSubField, df.Tag, i, -1, sf.Code, "%s$%c: undefined subfield", df.Tag, sf.Code)
+			case seen[sf.Code] && !repeatable:
+				v.add(Error, NonRepeatableSubField, df.Tag, i, -1, sf.Code, "%s$%c: subfield is not repeatable", df.Tag, sf.Code)
+			}
+		}
+		seen[sf.Code] = true
+		if sf.Value == "" {
+			v.add(Warning, EmptySubField, df.Tag, i, -1, sf.Code, "%s$%c: empty subfield", df.Tag, sf.Code)
+		}
+	}
+}
+
+// parseFields parses field definitions in the format described with the
+// bibliographic definition. It panics on malformed lines, as definitions
+// are compiled into the package.
+func parseFields(s string) map[string]*FieldDefinition {
+	fields := make(map[string]*FieldDefinition)
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		cols := strings.SplitN(line, " ", 7)
+		if len(cols) != 7 || len(cols[0]) != 3 || (cols[1] != "R" && cols[1] != "NR") {
+			panic(fmt.Sprintf("schema: invalid field definition %q", line))
+		}
+		def := &FieldDefinition{
+			Tag:        cols[0],
+			Name:       cols[6],
+			Repeatable: cols[1] == "R",
+			Control:    cols[2] == "-",
+		}
+		if fields[def.Tag] != nil {
+			panic(fmt.Sprintf("schema: duplicate field definition %q", line))
+		}
+		fields[def.Tag] = def
+		if k := strings.LastIndex(def.Name, " ["); k >= 0 && strings.HasSuffix(def.Name, "]") {
+			n, err := strconv.Atoi(def.Name[k+2 : len(def.Name)-1])
+			if err != nil {
+				panic(fmt.Sprintf("schema: invalid length in %q", line))
+			}
+			def.Name, def.Length = def.Name[:k], n
+		}
+		if def.Control {
+			continue
+		}
+		def.Ind1, def.Ind2 = indicatorValues(cols[2]), indicatorValues(cols[3])
+		if cols[4] == "*" {
+			continue
+		}
+		def.SubFields = make(map[byte]bool)
+		for _, c := range []byte(column(cols[4])) {
+			def.SubFields[c] = false
+		}
+		for _, c := range []byte(column(cols[5])) {
+			if _, ok := def.SubFields[c]; !ok {
+				panic(fmt.Sprintf("schema: repeatable subfield %c not defined in %q", c, line))
+			}
+			def.SubFields[c] = true
+		}
+	}
+	return fields
+}
+
+// column returns the value of a column, which is empty for "-".
+func column(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+// indicatorValues returns the values of an indicator column, with "#" as a
+// blank. Any value, "*", is returned as an empty string.
+func indicatorValues(s string) string {
+	if s == "*" {
+		return ""
+	}
+	return strings.Replace(s, "#", " ", -1)
+}
@@ -0,0 +1,157 @@
+package schema
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/miku/marc21"
+)
+
+// validRecord returns a minimal valid record with the fields the cases
+// change: control fields, a non-repeatable field (100) and a field with a
+// repeatable subfield (245 $n). The 880, which allows any indicators and
+// subfields, and the local 949 must not be reported.
+func validRecord() *marc21.Record {
+	sf := func(code byte, value string) *marc21.SubField {
+		return &marc21.SubField{Code: code, Value: value}
+	}
+	return &marc21.Record{
+		Leader: &marc21.Leader{
+			Status: 'n', Type: 'e', ImplementationDefined: [5]byte{'m', ' ', '7', 'a', ' '},
+			CharacterEncoding: 'a', IndicatorCount: 2, SubfieldCodeLength: 2,
+			LengthOfLength: 4, LengthOfStartPos: 5,
+		},
+		Fields: []marc21.Field{
+			&marc21.ControlField{Tag: "001", Data: "map-1595"},
+			&marc21.ControlField{Tag: "008", Data: "950101s1595    gw            a     lat d"},
+			&marc21.DataField{Tag: "100", Ind1: '1', Ind2: ' ', SubFields: []*marc21.SubField{
+				sf('a', "Mercator, Gerhard,"), sf('d', "1512-1594.")}},
+			&marc21.DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*marc21.SubField{
+				sf('a', "Atlas."), sf('n', "Pars 1."), sf('n', "Pars 2.")}},
+			&marc21.DataField{Tag: "880", Ind1: '9', Ind2: '9', SubFields: []*marc21.SubField{
+				sf('6', "245-01"), sf('Z', "any")}},
+			&marc21.DataField{Tag: "949", Ind1: 'x', Ind2: 'x', SubFields: []*marc21.SubField{sf('x', "local")}},
+		},
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	if issues := Validate(validRecord()); len(issues) > 0 {
+		t.Errorf("got %v, want no issues", issues)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var cases = []struct {
+		about  string
+		change func(r *marc21.Record)
+		want   []Issue
+	}{
+		{
+			"leader",
+			func(r *marc21.Record) { r.Leader.Type = 'x' },
+			[]Issue{{Error, InvalidLeader, "LDR", -1, 6, 0, "leader/06: undefined value 'x'"}},
+		},
+		{
+			"missing leader",
+			func(r *marc21.Record) { r.Leader = nil },
+			[]Issue{{Error, InvalidLeader, "LDR", -1, -1, 0, "leader missing"}},
+		},
+		{
+			"missing 245",
+			func(r *marc21.Record) { r.RemoveFields("245") },
+			[]Issue{{Error, MissingField, "245", -1, -1, 0, "245: mandatory field missing"}},
+		},
+		{
+			"unknown field",
+			func(r *marc21.Record) {
+				r.AddField(&marc21.DataField{Tag: "201", SubFields: []*marc21.SubField{{Code: 'a', Value: "x"}}})
+			},
+			[]Issue{{Warning, UnknownField, "201", 6, -1, 0, "201: undefined field"}},
+		},
+		{
+			"repeated 100",
+			func(r *marc21.Record) { r.AddField(r.Fields[2].(*marc21.DataField).Clone()) },
+			[]Issue{{Error, NonRepeatableField, "100", 6, -1, 0, "100: field is not repeatable"}},
+		},
+		{
+			"008 length",
+			func(r *marc21.Record) { r.Fields[1].(*marc21.ControlField).Data = "790104" },
+			[]Issue{{Error, InvalidControlField, "008", 1, -1, 0, "008: length 6, want 40"}},
+		},
+		{
+			"control field as data field",
+			func(r *marc21.Record) {
+				r.Fields[0] = &marc21.DataField{Tag: "001", SubFields: []*marc21.SubField{{Code: 'a', Value: "1"}}}
+			},
+			[]Issue{{Error, InvalidControlField, "001", 0, -1, 0, "001: data field, want control field"}},
+		},
+		{
+			"indicators",
+			func(r *marc21.Record) {
+				r.Fields[3].(*marc21.DataField).Ind1, r.Fields[3].(*marc21.DataField).Ind2 = '2', 'x'
+			},
+			[]Issue{
+				{Error, InvalidIndicator, "245", 3, 1, 0, "245: undefined indicator 1 '2'"},
+				{Error, InvalidIndicator, "245", 3, 2, 0, "245: undefined indicator 2 'x'"},
+			},
+		},
+		{
+			"subfields",
+			func(r *marc21.Record) {
+				df := r.Fields[3].(*marc21.DataField)
+				df.AddSubField('a', "Again")
+				df.AddSubField('e', "")
+			},
+			[]Issue{
+				{Error, NonRepeatableSubField, "245", 3, -1, 'a', "245$a: subfield is not repeatable"},
+				{Error, UnknownSubField, "245", 3, -1, 'e', "245$e: undefined subfield"},
+				{Warning, EmptySubField, "245", 3, -1, 'e', "245$e: empty subfield"},
+			},
+		},
+		{
+			"no subfields",
+			func(r *marc21.Record) { r.Fields[2].(*marc21.DataField).SubFields = nil },
+			[]Issue{{Error, MissingSubField, "100", 2, -1, 0, "100: no subfields"}},
+		},
+	}
+	for _, c := range cases {
+		record := validRecord()
+		c.change(record)
+		if got := Validate(record); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s, got %v, want %v", c.about, got, c.want)
+		}
+	}
+}
+
+func TestBibliographic(t *testing.T) {
+	def := Bibliographic.Fields["245"]
+	if def == nil || def.Name != "Title Statement" || def.Repeatable || def.Ind1 != "01" {
+		t.Errorf("245, got %+v", def)
+	}
+	if !def.SubFields['n'] || def.SubFields['a'] {
+		t.Errorf("245, got subfields %v, want $n repeatable and $a not", def.SubFields)
+	}
+	if def := Bibliographic.Fields["008"]; def == nil || !def.Control || def.Length != 40 {
+		t.Errorf("008, got %+v", def)
+	}
+	if def := Bibliographic.Fields["880"]; def == nil || def.SubFields != nil || def.Ind1 != "" {
+		t.Errorf("880, got %+v, want any indicators and subfields", def)
+	}
+}
+
+func TestValidateFixture(t *testing.T) {
+	file, err := os.Open("../fixtures/sandburg.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	record, err := marc21.ReadRecord(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := Validate(record); len(issues) > 0 {
+		t.Errorf("got %v, want no issues", issues)
+	}
+}
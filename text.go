@@ -0,0 +1,477 @@
+package marc21
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lookupMnemonic returns the value of a mnemonic, given without braces.
+// Besides named mnemonics, code points can be given as {U+00E9} or {233}.
+func lookupMnemonic(name string) (string, bool) {
+	if v, ok := specialMnemonics[name]; ok {
+		return v, true
+	}
+	if v, ok := characterMnemonics[name]; ok {
+		return v, true
+	}
+	if v, ok := entityMnemonics[name]; ok {
+		return v, true
+	}
+	var (
+		code uint64
+		err  error
+	)
+	switch {
+	case strings.HasPrefix(name, "U+"):
+		code, err = strconv.ParseUint(name[2:], 16, 32)
+	case len(name) > 0 && name[0] >= '0' && name[0] <= '9':
+		code, err = strconv.ParseUint(name, 10, 32)
+	default:
+		return "", false
+	}
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return "", false
+	}
+	return string(rune(code)), true
+}
+
+// decodeMnemonics replaces mnemonics in a value with the characters they
+// stand for. Diacritic mnemonics precede their base character and are moved
+// behind it. Unknown mnemonics are kept as they are.
+func decodeMnemonics(s string) string {
+	if strings.IndexByte(s, '{') < 0 {
+		return s
+	}
+	var (
+		buf     strings.Builder
+		pending []rune
+	)
+	write := func(v string) {
+		buf.WriteString(v)
+		for _, r := range pending {
+			buf.WriteRune(r)
+		}
+		pending = pending[:0]
+	}
+	for i := 0; i < len(s); {
+		if s[i] == '{' {
+			if j := strings.IndexByte(s[i:], '}'); j > 0 {
+				name := s[i+1 : i+j]
+				if r, ok := diacriticMnemonics[name]; ok {
+					pending = append(pending, r)
+					i += j + 1
+					continue
+				}
+				if v, ok := lookupMnemonic(name); ok {
+					write(v)
+					i += j + 1
+					continue
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		write(s[i : i+size])
+		i += size
+	}
+	write("")
+	return buf.String()
+}
+
+// decodeBlanks replaces backslashes, which stand for blanks in the leader,
+// fixed fields and indicators.
+func decodeBlanks(s string) string {
+	return strings.Replace(s, `\`, " ", -1)
+}
+
+// isSubfieldCode returns true, if c is a lowercase letter or digit, the
+// subfield codes of MARC21.
+func isSubfieldCode(c byte) bool {
+	return (c >= 'a' && c <= 'z') || isDigit(c)
+}
+
+// IsGraphicCode returns true for all printable ASCII characters except the
+// blank. It can be used as the IsSubfieldCode option of a TextReader to read
+// uppercase and other subfield codes found in local data.
+func IsGraphicCode(c byte) bool {
+	return c > ' ' && c < 0x7f
+}
+
+// isDelimiter returns true, if the dollar sign at position i of s starts a
+// subfield. A dollar sign not followed by a subfield code is taken literally,
+// and so are amounts like $15.95 or $1,000, where digits are followed by a
+// period or comma and another digit.
+func isDelimiter(s string, i int, isCode func(c byte) bool) bool {
+	if i+1 >= len(s) || !isCode(s[i+1]) {
+		return false
+	}
+	j := i + 1
+	for j < len(s) && isDigit(s[j]) {
+		j++
+	}
+	if j > i+1 && j+1 < len(s) && (s[j] == '.' || s[j] == ',') && isDigit(s[j+1]) {
+		return false
+	}
+	return true
+}
+
+// isDigit returns true, if c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseTextSubfields parses the subfields of a data field. The character
+// following the leading dollar sign is always taken as a subfield code, other
+// dollar signs only start a subfield, if isCode accepts the character
+// following them.
+func parseTextSubfields(s string, isCode func(c byte) bool) ([]*SubField, error) {
+	if len(s) < 2 || s[0] != '$' {
+		return nil, errors.New("data does not start with a subfield delimiter")
+	}
+	var subfields []*SubField
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i < len(s) && !(s[i] == '$' && isDelimiter(s, i, isCode)) {
+			continue
+		}
+		subfields = append(subfields, &SubField{
+			Code:  s[start+1],
+			Value: decodeMnemonics(s[start+2 : i]),
+		})
+		start = i
+	}
+	return subfields, nil
+}
+
+// fixupLeader fixes a 23 byte leader by adding a single byte into an
+// implementation specific position.
+func fixupLeader(s string) string {
+	if len(s) == 23 {
+		return s[:18] + " " + s[18:]
+	}
+	return s
+}
+
+// parseTextField parses a single line containing a field, like
+// "=245  10$aTitle". The leader is returned as a nil field.
+func parseTextField(line string, isControl func(tag string) bool, isCode func(c byte) bool) (Field, *Leader, error) {
+	if len(line) < 4 || line[0] != '=' {
+		return nil, nil, errors.New("field does not start with =")
+	}
+	tag, data := line[1:4], line[4:]
+	for i := 0; i < 2 && strings.HasPrefix(data, " "); i++ {
+		data = data[1:]
+	}
+	switch {
+	case tag == "LDR":
+		leader, err := parseLeaderString(fixupLeader(decodeBlanks(data)))
+		return nil, leader, err
+	case isControl(tag):
+		return &ControlField{Tag: tag, Data: decodeMnemonics(decodeBlanks(data))}, nil, nil
+	}
+	if len(data) < 2 {
+		return nil, nil, fmt.Errorf("data field %s without indicators", tag)
+	}
+	subfields, err := parseTextSubfields(data[2:], isCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data field %s: %s", tag, err)
+	}
+	return &DataField{
+		Tag:       tag,
+		Ind1:      decodeBlanks(data[0:1])[0],
+		Ind2:      decodeBlanks(data[1:2])[0],
+		SubFields: subfields,
+	}, nil, nil
+}
+
+// TextReader reads records in the MARC Breaker text format (.mrk), as
+// produced by LC MARCBreaker or MarcEdit:
+//
+//	=LDR  00586ngm a2200000i 4500
+//	=001  JoVEBiology50
+//	=245  12$aFreezing Human ES Cells$h[electronic resource]
+//
+// Each record starts with a leader line; blank lines between records are
+// ignored. Lines not starting with "=" continue the previous field. Backslashes stand
+// for blanks in the leader, control fields and indicators. Mnemonics like
+// {dollar}, {eacute}, {U+00E9} or {233} are replaced by the characters they
+// represent.
+//
+// A dollar sign within a field starts a subfield only, if it is followed by
+// a subfield code, and not if it starts an amount like $15.95. By default,
+// only the MARC21 subfield codes, lowercase letters and digits, are
+// recognized there, so a dollar sign before an uppercase letter is kept as
+// text; see IsSubfieldCode.
+type TextReader struct {
+	// IsControlField, if not nil, decides which tags are read as control
+	// fields. By default, IsControlTag is used.
+	IsControlField func(tag string) bool
+	// IsSubfieldCode, if not nil, decides which characters following a
+	// dollar sign within a field are subfield codes. By default, these are
+	// lowercase letters and digits; IsGraphicCode accepts uppercase and
+	// other codes as well. The first subfield code of a field is always
+	// accepted.
+	IsSubfieldCode func(c byte) bool
+
+	br     *bufio.Reader
+	line   int
+	peeked *textLine
+	record *Record
+	err    error
+	count  int
+}
+
+// textLine is a line of input along with its line number.
+type textLine struct {
+	s string
+	n int
+}
+
+// NewTextReader returns a new TextReader that reads from r.
+func NewTextReader(r io.Reader) *TextReader {
+	return &TextReader{br: bufio.NewReader(r)}
+}
+
+// readLine returns the next line without line ending.
+func (r *TextReader) readLine() (textLine, error) {
+	if r.peeked != nil {
+		line := *r.peeked
+		r.peeked = nil
+		return line, nil
+	}
+	s, err := r.br.ReadString('\n')
+	if err == io.EOF && len(s) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return textLine{}, err
+	}
+	r.line++
+	return textLine{s: strings.TrimRight(s, "\r\n"), n: r.line}, nil
+}
+
+// readContinuation appends the continuation lines of a field. Field values
+// may contain line breaks, so lines not starting with "=" continue the
+// field, blank lines included; trailing blank lines are dropped.
+func (r *TextReader) readContinuation(line *textLine) error {
+	for {
+		next, err := r.readLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(next.s, "=") {
+			r.peeked = &next
+			break
+		}
+		line.s += "\n" + next.s
+	}
+	line.s = strings.TrimRight(line.s, "\r\n")
+	return nil
+}
+
+// isBlank returns true, if the line contains only whitespace.
+func isBlank(s string) bool {
+	return len(strings.TrimSpace(s)) == 0
+}
+
+// Next returns the next record. It returns io.EOF, if there are no more
+// records.
+func (r *TextReader) Next() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var record *Record
+	for {
+		line, err := r.readLine()
+		if err == io.EOF && record != nil {
+			break
+		}
+		if err != nil {
+			r.err = err
+			return nil, err
+		}
+		if isBlank(line.s) {
+			continue
+		}
+		if record != nil && strings.HasPrefix(line.s, "=LDR") {
+			r.peeked = &line
+			break
+		}
+		if record == nil {
+			record = &Record{}
+		}
+		if strings.HasPrefix(line.s, "=") {
+			if err := r.readContinuation(&line); err != nil {
+				r.err = err
+				return nil, err
+			}
+		}
+		isControl := r.IsControlField
+		if isControl == nil {
+			isControl = IsControlTag
+		}
+		isCode := r.IsSubfieldCode
+		if isCode == nil {
+			isCode = isSubfieldCode
+		}
+		field, leader, err := parseTextField(line.s, isControl, isCode)
+		if err != nil {
+			r.err = fmt.Errorf("line %d: %s", line.n, err)
+			return nil, r.err
+		}
+		if leader != nil {
+			record.Leader = leader
+		} else {
+			record.AddField(field)
+		}
+	}
+	if record.Leader == nil {
+		record.Leader = defaultLeader()
+	}
+	r.count++
+	r.record = record
+	return record, nil
+}
+
+// Scan advances the reader to the next record, which will then be available
+// through the Record method. It returns false when the input is exhausted or
+// an error occurred.
+func (r *TextReader) Scan() bool {
+	_, err := r.Next()
+	return err == nil
+}
+
+// Record returns the most recent record read by a call to Scan or Next.
+func (r *TextReader) Record() *Record {
+	return r.record
+}
+
+// Err returns the first non-EOF error that was encountered by the reader.
+func (r *TextReader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Count returns the number of records read so far.
+func (r *TextReader) Count() int {
+	return r.count
+}
+
+// TextWriter writes records in the MARC Breaker text format (.mrk), which
+// can be read back with a TextReader. Records are separated by blank lines.
+type TextWriter struct {
+	// UTF8, if true, writes non-ASCII characters as they are. Otherwise
+	// mnemonics like {eacute} or {acute}e are used, and {U+XXXX} for
+	// characters without a name. The characters $, {, } and \ are always
+	// written as mnemonics.
+	UTF8 bool
+	// IsControlField, if not nil, is checked against the type of each
+	// field, so that records can be read back with the same predicate.
+	IsControlField func(tag string) bool
+
+	w io.Writer
+}
+
+// NewTextWriter returns a new TextWriter that writes to w.
+func NewTextWriter(w io.Writer) *TextWriter {
+	return &TextWriter{w: w}
+}
+
+// Write writes a single record, followed by a blank line.
+func (w *TextWriter) Write(record *Record) error {
+	var buf strings.Builder
+	leader := record.Leader
+	if leader == nil {
+		leader = defaultLeader()
+	}
+	buf.WriteString("=LDR  ")
+	buf.WriteString(w.encode(leader.String(), true))
+	buf.WriteByte('\n')
+	for _, f := range record.Fields {
+		if err := checkFieldKind(f, w.IsControlField); err != nil {
+			return err
+		}
+		buf.WriteString("=" + f.GetTag() + "  ")
+		switch field := f.(type) {
+		case *ControlField:
+			buf.WriteString(w.encode(field.Data, true))
+		case *DataField:
+			buf.WriteString(w.encode(string([]byte{field.Ind1, field.Ind2}), true))
+			for _, sf := range field.SubFields {
+				buf.WriteByte('$')
+				buf.WriteByte(sf.Code)
+				buf.WriteString(w.encode(sf.Value, false))
+			}
+		default:
+			return fmt.Errorf("unsupported field type %T", f)
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := io.WriteString(w.w, buf.String())
+	return err
+}
+
+// encodeRune writes a single character, using a mnemonic if needed.
+func (w *TextWriter) encodeRune(buf *strings.Builder, r rune, blanks bool) {
+	switch {
+	case r == ' ' && blanks:
+		buf.WriteByte('\\')
+	case r == '$' || r == '{' || r == '}' || r == '\\':
+		buf.WriteString("{" + mnemonicNames[r] + "}")
+	case r >= 0x20 && r < 0x7f:
+		buf.WriteRune(r)
+	case w.UTF8 && unicode.IsPrint(r):
+		buf.WriteRune(r)
+	case mnemonicNames[r] != "":
+		buf.WriteString("{" + mnemonicNames[r] + "}")
+	default:
+		fmt.Fprintf(buf, "{U+%04X}", r)
+	}
+}
+
+// encode replaces characters by mnemonics. If blanks is true, blanks are
+// written as backslashes, as in the leader, control fields and indicators.
+// Without the UTF8 option, combining characters are placed before their
+// base character, as MARC Breaker expects.
+func (w *TextWriter) encode(s string, blanks bool) string {
+	var buf strings.Builder
+	if w.UTF8 {
+		for _, r := range s {
+			w.encodeRune(&buf, r, blanks)
+		}
+		return buf.String()
+	}
+	var runes []rune
+	for _, r := range s {
+		if _, ok := mnemonicNames[r]; !ok {
+			if d, ok := decompositions[r]; ok {
+				runes = append(runes, []rune(d)...)
+				continue
+			}
+		}
+		runes = append(runes, r)
+	}
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && unicode.In(runes[j], unicode.Mn, unicode.Me) {
+			j++
+		}
+		for _, r := range runes[i+1 : j] {
+			w.encodeRune(&buf, r, blanks)
+		}
+		w.encodeRune(&buf, runes[i], blanks)
+		i = j
+	}
+	return buf.String()
+}
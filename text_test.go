@@ -0,0 +1,237 @@
+package marc21
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMnemonics(t *testing.T) {
+	var cases = []struct {
+		s   string
+		exp string
+	}{
+		{"plain", "plain"},
+		{"{dollar}15.95", "$15.95"},
+		{"{lcub}x{rcub}", "{x}"},
+		{"Caf{eacute}", "Café"},
+		{"Caf{233}", "Café"},
+		{"Caf{U+00E9}", "Café"},
+		{"Caf{acute}e", "Café"},
+		{"{Lstrok}{oacute}d{acute}z", "Łódź"},
+		{"{unknown} {", "{unknown} {"},
+		{"{esc}(N", "\x1b(N"},
+	}
+	for _, c := range cases {
+		if got := decodeMnemonics(c.s); got != c.exp {
+			t.Errorf("decodeMnemonics(%q), got %q, want %q", c.s, got, c.exp)
+		}
+	}
+}
+
+func TestParseTextSubfields(t *testing.T) {
+	var cases = []struct {
+		s   string
+		exp []string
+	}{
+		{"$aTitle", []string{"aTitle"}},
+		{"$aTitle$h[electronic resource]", []string{"aTitle", "h[electronic resource]"}},
+		{"$aPrice $15.95$cnote", []string{"aPrice $15.95", "cnote"}},
+		{"$aUS$ 100$2lcsh", []string{"aUS$ 100", "2lcsh"}},
+		{"$fLI$221", []string{"fLI", "221"}},
+		{"$a$1,000 or $5.00", []string{"a$1,000 or $5.00"}},
+		{"$a{dollar}b", []string{"a$b"}},
+		{"$a", []string{"a"}},
+		{"$aTitle$Bnote", []string{"aTitle$Bnote"}},
+		{"$ATitle$bnote", []string{"ATitle", "bnote"}},
+	}
+	for _, c := range cases {
+		subfields, err := parseTextSubfields(c.s, isSubfieldCode)
+		if err != nil {
+			t.Errorf("parseTextSubfields(%q) failed: %s", c.s, err)
+			continue
+		}
+		var got []string
+		for _, sf := range subfields {
+			got = append(got, string(sf.Code)+sf.Value)
+		}
+		if strings.Join(got, "|") != strings.Join(c.exp, "|") {
+			t.Errorf("parseTextSubfields(%q), got %q, want %q", c.s, got, c.exp)
+		}
+	}
+	if _, err := parseTextSubfields("Title", isSubfieldCode); err == nil {
+		t.Errorf("parseTextSubfields without delimiter, got nil error")
+	}
+}
+
+func TestTextReader(t *testing.T) {
+	const doc = `=LDR  00586ngm a2200000i 4500
+=001  JoVEBiology50
+=008  161201s2016\\\\xx\\\\\g\\\\\\s\\\\\eng\d
+=100  1\$aTrish,Erin
+=245  12$aFreezing Human ES Cells$h[electronic resource]
+=520  \\$aFirst line
+second line
+
+=LDR  00000nam\\2200000\i\4500
+=001  2
+=650  \0$aCaf{eacute}s
+`
+	r := NewTextReader(strings.NewReader(doc))
+	var records []*Record
+	for r.Scan() {
+		records = append(records, r.Record())
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	if len(records) != 2 {
+		t.Fatalf("records, got %d, want 2", len(records))
+	}
+	first := records[0]
+	if first.Identifier() != "JoVEBiology50" {
+		t.Errorf("identifier, got %q, want %q", first.Identifier(), "JoVEBiology50")
+	}
+	if s := first.GetFields("008")[0].(*ControlField).Data; s != "161201s2016    xx     g      s     eng d" {
+		t.Errorf("008, got %q", s)
+	}
+	df := first.GetFields("100")[0].(*DataField)
+	if df.Ind1 != '1' || df.Ind2 != ' ' {
+		t.Errorf("indicators, got %q %q, want '1' ' '", df.Ind1, df.Ind2)
+	}
+	if v := first.GetSubFields("245", 'h'); len(v) != 1 || v[0].Value != "[electronic resource]" {
+		t.Errorf("245 $h, got %v", v)
+	}
+	if v := first.GetSubFields("520", 'a'); len(v) != 1 || v[0].Value != "First line\nsecond line" {
+		t.Errorf("520 $a, got %v", v)
+	}
+	second := records[1]
+	if s := second.Leader.String(); s != "00000nam  2200000 i 4500" {
+		t.Errorf("leader, got %q", s)
+	}
+	if v := second.GetSubFields("650", 'a'); len(v) != 1 || v[0].Value != "Cafés" {
+		t.Errorf("650 $a, got %v", v)
+	}
+}
+
+func TestTextReaderError(t *testing.T) {
+	const doc = "=LDR  00586ngm a2200000i 4500\n=245  12Title\n"
+	r := NewTextReader(strings.NewReader(doc))
+	if r.Scan() {
+		t.Fatalf("Scan, got true, want false")
+	}
+	if r.Err() == nil || !strings.HasPrefix(r.Err().Error(), "line 2:") {
+		t.Errorf("error, got %v, want error on line 2", r.Err())
+	}
+}
+
+func TestTextReaderFixture(t *testing.T) {
+	file, err := os.Open("fixtures/marc.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r := NewTextReader(file)
+	for r.Scan() {
+	}
+	if r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	if r.Count() != 2820 {
+		t.Errorf("count, got %d, want 2820", r.Count())
+	}
+}
+
+func TestTextWriter(t *testing.T) {
+	record := &Record{Leader: defaultLeader()}
+	record.AddField(&ControlField{Tag: "008", Data: "920219s1993    caua"})
+	record.AddField(&DataField{Tag: "020", Ind1: ' ', Ind2: ' ', SubFields: []*SubField{
+		{Code: 'a', Value: "0152038655 :"},
+		{Code: 'c', Value: "$15.95 {x}"},
+	}})
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "Łódź, Café, Café, ☃"},
+	}})
+	var cases = []struct {
+		utf8 bool
+		exp  string
+	}{
+		{false, `=LDR  00000nam\a2200000\\\4500
+=008  920219s1993\\\\caua
+=020  \\$a0152038655 :$c{dollar}15.95 {lcub}x{rcub}
+=245  10$a{Lstrok}{oacute}d{acute}z, Caf{eacute}, Caf{acute}e, {U+2603}
+
+`},
+		{true, `=LDR  00000nam\a2200000\\\4500
+=008  920219s1993\\\\caua
+=020  \\$a0152038655 :$c{dollar}15.95 {lcub}x{rcub}
+=245  10$aŁódź, Café, Cafe` + "́" + `, ☃
+
+`},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		w := NewTextWriter(&buf)
+		w.UTF8 = c.utf8
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != c.exp {
+			t.Errorf("UTF8 %v, got %q, want %q", c.utf8, buf.String(), c.exp)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, utf8 := range []bool{false, true} {
+		var buf bytes.Buffer
+		tw := NewTextWriter(&buf)
+		tw.UTF8 = utf8
+		var records []*Record
+		r := NewReader(openTestMARC(t))
+		for r.Scan() {
+			records = append(records, r.Record())
+			if err := tw.Write(r.Record()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if r.Err() != nil {
+			t.Fatal(r.Err())
+		}
+		tr := NewTextReader(&buf)
+		for i := 0; tr.Scan(); i++ {
+			got, want := tr.Record(), records[i]
+			if got.String() != want.String() {
+				t.Errorf("UTF8 %v, record %d, got %q, want %q", utf8, i, got, want)
+			}
+		}
+		if tr.Err() != nil {
+			t.Fatal(tr.Err())
+		}
+		if tr.Count() != len(records) {
+			t.Errorf("UTF8 %v, count, got %d, want %d", utf8, tr.Count(), len(records))
+		}
+	}
+}
+
+func TestTextReaderIsSubfieldCode(t *testing.T) {
+	const doc = "=LDR  00000nam\\\\2200000\\\\\\4500\n=999  \\\\$aLocal$Bshelf$cUS$ 10\n"
+	var cases = []struct {
+		isCode func(c byte) bool
+		exp    string
+	}{
+		{nil, "999 [  ] [(a) Local$Bshelf], [(c) US$ 10]"},
+		{IsGraphicCode, "999 [  ] [(a) Local], [(B) shelf], [(c) US$ 10]"},
+	}
+	for _, c := range cases {
+		r := NewTextReader(strings.NewReader(doc))
+		r.IsSubfieldCode = c.isCode
+		if !r.Scan() {
+			t.Fatal(r.Err())
+		}
+		if got := r.Record().Fields[0].String(); got != c.exp {
+			t.Errorf("got %v, want %v", got, c.exp)
+		}
+	}
+}
@@ -0,0 +1,644 @@
+// Package unimarc converts UNIMARC bibliographic records to MARC21.
+//
+// The crosswalk covers the commonly used blocks: identifiers (0XX), coded
+// data (100, 101, 102), descriptive information (200-225), notes (3XX),
+// series links (410), subjects and classification (6XX), responsibility
+// (7XX) and the originating source (801). Fields outside of the crosswalk
+// are collected in a Report, local 9XX fields are copied unchanged.
+//
+// The character set of the values is not converted. Records should be read
+// as UTF-8 (100$a/26-27 "50"), other character sets are listed in the notes
+// of the report.
+package unimarc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miku/marc21"
+)
+
+const (
+	// nsb and nse mark the beginning and end of characters to be ignored
+	// in sorting, like an initial article.
+	nsb = "\u0098"
+	nse = "\u009c"
+)
+
+// Report lists the parts of a UNIMARC record, that could not be converted.
+type Report struct {
+	// Unmapped contains the fields, that have no MARC21 equivalent in the
+	// crosswalk.
+	Unmapped []marc21.Field
+	// UnmappedSubfields lists the subfields of converted fields, that were
+	// dropped, as tag and code, e.g. "200$z".
+	UnmappedSubfields []string
+	// Notes contains other problems, like an unsupported character set.
+	Notes []string
+}
+
+// Empty returns true, if the record was converted without loss.
+func (r *Report) Empty() bool {
+	return len(r.Unmapped) == 0 && len(r.UnmappedSubfields) == 0 && len(r.Notes) == 0
+}
+
+// mapping maps a UNIMARC data field to a MARC21 data field by subfield code.
+type mapping struct {
+	tag        string
+	ind1, ind2 byte
+	codes      map[byte]byte
+}
+
+// mappings are the fields, that can be converted subfield by subfield.
+var mappings = map[string]mapping{
+	"010": {"020", ' ', ' ', map[byte]byte{'a': 'a', 'b': 'q', 'd': 'c', 'z': 'z'}},
+	"011": {"022", ' ', ' ', map[byte]byte{'a': 'a', 'y': 'y', 'z': 'z'}},
+	"215": {"300", ' ', ' ', map[byte]byte{'a': 'a', 'c': 'b', 'd': 'c', 'e': 'e'}},
+	"225": {"490", '0', ' ', map[byte]byte{'a': 'a', 'v': 'v', 'x': 'x'}},
+	"300": {"500", ' ', ' ', map[byte]byte{'a': 'a'}},
+	"320": {"504", ' ', ' ', map[byte]byte{'a': 'a'}},
+	"327": {"505", '0', ' ', map[byte]byte{'a': 'a'}},
+	"330": {"520", ' ', ' ', map[byte]byte{'a': 'a'}},
+	"410": {"830", ' ', '0', map[byte]byte{'t': 'a', 'v': 'v', 'x': 'x'}},
+	"610": {"653", ' ', ' ', map[byte]byte{'a': 'a'}},
+	"675": {"080", ' ', ' ', map[byte]byte{'a': 'a', 'v': '2'}},
+	"676": {"082", '0', '4', map[byte]byte{'a': 'a', 'v': '2'}},
+	"680": {"050", ' ', '4', map[byte]byte{'a': 'a', 'b': 'b'}},
+}
+
+// punctuation is the ISBD punctuation, that MARC21 records carry and UNIMARC
+// records leave to the display. It is appended to the value preceding a
+// subfield with the given code.
+var punctuation = map[string]map[byte]string{
+	"245": {'b': " :", 'c': " /", 'n': ".", 'p': ","},
+	"250": {'b': " /"},
+	"260": {'a': " ;", 'b': " :", 'c': ","},
+	"300": {'b': " :", 'c': " ;", 'e': " +"},
+}
+
+// subdivisions maps the subject subdivisions of the 6XX block.
+var subdivisions = map[byte]byte{'j': 'v', 'x': 'x', 'y': 'z', 'z': 'y', '2': '2', '3': '0'}
+
+// subjects maps the subject access fields to MARC21 tags.
+var subjects = map[string]string{
+	"600": "600", "601": "610", "602": "600", "604": "600",
+	"605": "630", "606": "650", "607": "651", "608": "655",
+}
+
+// relators maps the numeric UNIMARC relator codes to MARC21 relator codes.
+var relators = map[string]string{
+	"005": "act", "010": "adp", "020": "ann", "070": "aut", "072": "aqt",
+	"080": "aui", "100": "ant", "205": "ctb", "212": "cmm", "220": "com",
+	"230": "cmp", "245": "ccp", "250": "cnd", "273": "cur", "290": "dte",
+	"295": "dgg", "300": "drt", "340": "edt", "365": "exp", "370": "flm",
+	"440": "ill", "460": "ive", "470": "ivr", "520": "lyr", "550": "nrt",
+	"570": "oth", "600": "pht", "610": "prt", "650": "pbl", "651": "pbd",
+	"673": "rth", "675": "rev", "700": "scr", "705": "scl", "710": "red",
+	"720": "sgn", "721": "sng", "723": "spn", "727": "ths", "730": "trl",
+}
+
+// dateTypes maps the type of publication date (100$a/08) to 008/06.
+var dateTypes = map[byte]byte{
+	'a': 'c', 'b': 'd', 'c': 'u', 'd': 's', 'e': 'r', 'f': 'q',
+	'g': 'm', 'h': 't', 'i': 'p', 'j': 'e', 'u': 'n',
+}
+
+// converter holds the state of a single conversion.
+type converter struct {
+	out       *marc21.Record
+	report    *Report
+	f008      []byte
+	langs     []string
+	f040      *marc21.DataField
+	mainEntry bool
+}
+
+// Convert converts a UNIMARC bibliographic record to MARC21. The returned
+// report lists fields and subfields that were not converted. The input
+// record is not modified.
+func Convert(record *marc21.Record) (*marc21.Record, *Report) {
+	c := &converter{
+		out:    &marc21.Record{Leader: convertLeader(record.Leader)},
+		report: &Report{},
+		f008:   []byte(strings.Repeat(" ", 40)),
+	}
+	copy(c.f008[15:18], "xx ")
+	copy(c.f008[35:40], "und d")
+	for _, f := range record.Fields {
+		switch field := f.(type) {
+		case *marc21.ControlField:
+			c.controlField(field)
+		case *marc21.DataField:
+			c.dataField(field)
+		}
+	}
+	c.finish()
+	return c.out, c.report
+}
+
+// convertLeader maps the leader. UNIMARC and MARC21 share the record status,
+// type and bibliographic level; encoding level and descriptive form are
+// translated.
+func convertLeader(in *marc21.Leader) *marc21.Leader {
+	leader := &marc21.Leader{
+		Status:                'n',
+		Type:                  'a',
+		ImplementationDefined: [5]byte{'m', ' ', ' ', 'i', ' '},
+		CharacterEncoding:     'a',
+		IndicatorCount:        2,
+		SubfieldCodeLength:    2,
+		LengthOfLength:        4,
+		LengthOfStartPos:      5,
+	}
+	if in == nil {
+		return leader
+	}
+	leader.Status, leader.Type = in.Status, in.Type
+	leader.ImplementationDefined[0] = in.ImplementationDefined[0]
+	switch in.ImplementationDefined[2] {
+	case '1':
+		leader.ImplementationDefined[2] = '1'
+	case '2':
+		leader.ImplementationDefined[2] = '8'
+	case '3':
+		leader.ImplementationDefined[2] = '7'
+	}
+	if in.ImplementationDefined[3] == 'n' {
+		leader.ImplementationDefined[3] = ' '
+	}
+	return leader
+}
+
+// stripNonSort removes the non-sorting markers from a value. It returns the
+// number of characters to be ignored in sorting at the start of the value.
+func stripNonSort(s string) (string, int) {
+	var skip int
+	if strings.HasPrefix(s, nsb) {
+		if i := strings.Index(s, nse); i > 0 {
+			skip = utf8.RuneCountInString(s[len(nsb):i])
+		}
+	}
+	s = strings.Replace(s, nsb, "", -1)
+	return strings.Replace(s, nse, "", -1), skip
+}
+
+// unmappedSubfield records a dropped subfield.
+func (c *converter) unmappedSubfield(tag string, code byte) {
+	c.report.UnmappedSubfields = append(c.report.UnmappedSubfields, fmt.Sprintf("%s$%c", tag, code))
+}
+
+// add appends a subfield, adding ISBD punctuation to the previous one.
+func add(df *marc21.DataField, code byte, value string) {
+	value, _ = stripNonSort(value)
+	if n := len(df.SubFields); n > 0 {
+		if p, ok := punctuation[df.Tag][code]; ok {
+			prev := df.SubFields[n-1]
+			if !strings.HasSuffix(prev.Value, strings.TrimSpace(p)) {
+				prev.Value += p
+			}
+		}
+	}
+	df.SubFields = append(df.SubFields, &marc21.SubField{Code: code, Value: value})
+}
+
+// combine appends a value to the first subfield with the given code,
+// separated by sep, or adds a subfield, if there is none. The ISBD
+// punctuation between subfields is left to add.
+func combine(subfields []*marc21.SubField, code byte, sep, value string) []*marc21.SubField {
+	value, _ = stripNonSort(value)
+	for _, sf := range subfields {
+		if sf.Code == code {
+			sf.Value += sep + value
+			return subfields
+		}
+	}
+	return append(subfields, &marc21.SubField{Code: code, Value: value})
+}
+
+// has returns true, if there is a subfield with the given code.
+func has(subfields []*marc21.SubField, code byte) bool {
+	for _, sf := range subfields {
+		if sf.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// first returns the value of the first subfield with the given code.
+func first(df *marc21.DataField, code byte) string {
+	for _, sf := range df.SubFields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+func (c *converter) controlField(cf *marc21.ControlField) {
+	switch cf.Tag {
+	case "001", "005":
+		c.out.AddField(&marc21.ControlField{Tag: cf.Tag, Data: cf.Data})
+	default:
+		c.report.Unmapped = append(c.report.Unmapped, cf)
+	}
+}
+
+func (c *converter) dataField(df *marc21.DataField) {
+	if m, ok := mappings[df.Tag]; ok {
+		out := &marc21.DataField{Tag: m.tag, Ind1: m.ind1, Ind2: m.ind2}
+		for _, sf := range df.SubFields {
+			code, ok := m.codes[sf.Code]
+			if !ok {
+				c.unmappedSubfield(df.Tag, sf.Code)
+				continue
+			}
+			add(out, code, sf.Value)
+		}
+		if len(out.SubFields) > 0 {
+			c.out.AddField(out)
+		}
+		return
+	}
+	if _, ok := subjects[df.Tag]; ok {
+		c.subject(df)
+		return
+	}
+	switch {
+	case df.Tag == "100":
+		c.generalData(df)
+	case df.Tag == "101":
+		c.language(df)
+	case df.Tag == "102":
+		if v := first(df, 'a'); v != "" {
+			c.out.AddField(&marc21.DataField{Tag: "044", Ind1: ' ', Ind2: ' ',
+				SubFields: []*marc21.SubField{{Code: 'c', Value: v}}})
+		}
+	case df.Tag == "200":
+		c.title(df)
+	case df.Tag == "205":
+		c.edition(df)
+	case df.Tag == "210":
+		c.publication(df)
+	case df.Tag == "801":
+		c.source(df)
+	case df.Tag == "856" || strings.HasPrefix(df.Tag, "9"):
+		c.out.AddField(copyField(df))
+	case strings.HasPrefix(df.Tag, "7"):
+		c.responsibility(df)
+	default:
+		c.report.Unmapped = append(c.report.Unmapped, df)
+	}
+}
+
+// copyField returns a copy of a data field.
+func copyField(df *marc21.DataField) *marc21.DataField {
+	out := &marc21.DataField{Tag: df.Tag, Ind1: df.Ind1, Ind2: df.Ind2}
+	for _, sf := range df.SubFields {
+		out.SubFields = append(out.SubFields, &marc21.SubField{Code: sf.Code, Value: sf.Value})
+	}
+	return out
+}
+
+// generalData converts the general processing data (100$a) into 008 and
+// the character set into the leader.
+func (c *converter) generalData(df *marc21.DataField) {
+	v := first(df, 'a')
+	if len(v) < 17 {
+		c.report.Notes = append(c.report.Notes, fmt.Sprintf("invalid general processing data %q", v))
+		return
+	}
+	copy(c.f008[0:6], v[2:8])
+	if t, ok := dateTypes[v[8]]; ok {
+		c.f008[6] = t
+	}
+	copy(c.f008[7:15], v[9:17])
+	if len(v) >= 25 {
+		c.f040Field().SubFields = append(c.f040Field().SubFields,
+			&marc21.SubField{Code: 'b', Value: strings.TrimSpace(v[22:25])})
+	}
+	if len(v) >= 28 && v[26:28] != "50" {
+		c.out.Leader.CharacterEncoding = ' '
+		c.report.Notes = append(c.report.Notes, fmt.Sprintf("character set %q not converted", v[26:28]))
+	}
+}
+
+// language converts the language of the item into 008 and 041.
+func (c *converter) language(df *marc21.DataField) {
+	out := &marc21.DataField{Tag: "041", Ind1: '0', Ind2: ' '}
+	if df.Ind1 == '1' {
+		out.Ind1 = '1'
+	}
+	for _, sf := range df.SubFields {
+		switch sf.Code {
+		case 'a':
+			c.langs = append(c.langs, sf.Value)
+			add(out, 'a', sf.Value)
+		case 'c':
+			add(out, 'h', sf.Value)
+		default:
+			c.unmappedSubfield(df.Tag, sf.Code)
+		}
+	}
+	if len(c.langs) > 0 {
+		copy(c.f008[35:38], c.langs[0])
+	}
+	if len(c.langs) > 1 || out.Ind1 == '1' {
+		c.out.AddField(out)
+	}
+}
+
+// title converts the title and statement of responsibility (200) to 245,
+// parallel titles to 246. The subfields $a, $b, $c and $h of 245 are not
+// repeatable: repeated titles, other title information and statements of
+// responsibility are combined with ISBD punctuation, a repeated general
+// material designation is reported.
+func (c *converter) title(df *marc21.DataField) {
+	out := &marc21.DataField{Tag: "245", Ind1: '0', Ind2: '0'}
+	var (
+		subfields []*marc21.SubField
+		parallel  []string
+	)
+	for _, sf := range df.SubFields {
+		switch sf.Code {
+		case 'a':
+			if len(subfields) == 0 {
+				_, skip := stripNonSort(sf.Value)
+				if skip > 9 {
+					skip = 9
+				}
+				out.Ind2 = byte('0' + skip)
+			}
+			subfields = combine(subfields, 'a', " ; ", sf.Value)
+		case 'b':
+			if has(subfields, 'h') {
+				c.unmappedSubfield(df.Tag, sf.Code)
+				continue
+			}
+			subfields = combine(subfields, 'h', "", "["+sf.Value+"]")
+		case 'd':
+			parallel = append(parallel, sf.Value)
+		case 'e':
+			subfields = combine(subfields, 'b', " : ", sf.Value)
+		case 'f', 'g':
+			subfields = combine(subfields, 'c', " ; ", sf.Value)
+		case 'h':
+			subfields = append(subfields, &marc21.SubField{Code: 'n', Value: sf.Value})
+		case 'i':
+			subfields = append(subfields, &marc21.SubField{Code: 'p', Value: sf.Value})
+		default:
+			c.unmappedSubfield(df.Tag, sf.Code)
+		}
+	}
+	for _, sf := range subfields {
+		add(out, sf.Code, sf.Value)
+	}
+	if len(out.SubFields) > 0 {
+		c.out.AddField(out)
+	}
+	for _, v := range parallel {
+		v, _ = stripNonSort(v)
+		c.out.AddField(&marc21.DataField{Tag: "246", Ind1: '3', Ind2: '1',
+			SubFields: []*marc21.SubField{{Code: 'a', Value: v}}})
+	}
+}
+
+// edition converts the edition statement (205) to 250. Both subfields of
+// 250 are not repeatable: additional edition statements are appended to $a,
+// all statements of responsibility are combined in $b.
+func (c *converter) edition(df *marc21.DataField) {
+	out := &marc21.DataField{Tag: "250", Ind1: ' ', Ind2: ' '}
+	var subfields []*marc21.SubField
+	for _, sf := range df.SubFields {
+		switch sf.Code {
+		case 'a', 'b':
+			subfields = combine(subfields, 'a', ", ", sf.Value)
+		case 'f', 'g':
+			subfields = combine(subfields, 'b', " ; ", sf.Value)
+		default:
+			c.unmappedSubfield(df.Tag, sf.Code)
+		}
+	}
+	for _, sf := range subfields {
+		add(out, sf.Code, sf.Value)
+	}
+	if len(out.SubFields) > 0 {
+		c.out.AddField(out)
+	}
+}
+
+// publication converts the publication area (210) to 260.
+func (c *converter) publication(df *marc21.DataField) {
+	codes := map[byte]byte{'a': 'a', 'c': 'b', 'd': 'c', 'e': 'e', 'g': 'f', 'h': 'g'}
+	out := &marc21.DataField{Tag: "260", Ind1: ' ', Ind2: ' '}
+	for _, sf := range df.SubFields {
+		code, ok := codes[sf.Code]
+		if !ok {
+			c.unmappedSubfield(df.Tag, sf.Code)
+			continue
+		}
+		add(out, code, sf.Value)
+	}
+	if len(out.SubFields) > 0 {
+		c.out.AddField(out)
+	}
+}
+
+// name converts the subfields of a personal (kind 'p'), corporate ('c') or
+// family ('f') name, adding the other subfields with the given mapping.
+func (c *converter) name(df *marc21.DataField, out *marc21.DataField, kind byte, other map[byte]byte) {
+	var codes map[byte]byte
+	switch kind {
+	case 'p':
+		codes = map[byte]byte{'c': 'c', 'd': 'b', 'f': 'd', 'g': 'q', '3': '0'}
+	case 'c':
+		codes = map[byte]byte{'a': 'a', 'b': 'b', 'c': 'g', 'd': 'n', 'e': 'c', 'f': 'd', '3': '0'}
+	case 'f':
+		codes = map[byte]byte{'a': 'a', 'c': 'c', 'f': 'd', '3': '0'}
+	}
+	for _, sf := range df.SubFields {
+		switch {
+		case kind == 'p' && sf.Code == 'a':
+			value := sf.Value
+			if b := first(df, 'b'); b != "" {
+				value += ", " + b
+			}
+			add(out, 'a', value)
+		case kind == 'p' && sf.Code == 'b':
+		case sf.Code == '4':
+			if code, ok := relators[sf.Value]; ok {
+				add(out, '4', code)
+			} else {
+				c.unmappedSubfield(df.Tag, sf.Code)
+			}
+		case codes[sf.Code] != 0:
+			add(out, codes[sf.Code], sf.Value)
+		case other[sf.Code] != 0:
+			add(out, other[sf.Code], sf.Value)
+		default:
+			c.unmappedSubfield(df.Tag, sf.Code)
+		}
+	}
+}
+
+// nameIndicator returns the first indicator of a MARC21 name field.
+func nameIndicator(df *marc21.DataField, kind byte) byte {
+	switch kind {
+	case 'p':
+		if df.Ind2 == '0' {
+			return '0'
+		}
+		return '1'
+	case 'f':
+		return '3'
+	}
+	if df.Ind2 == '0' || df.Ind2 == '1' || df.Ind2 == '2' {
+		return df.Ind2
+	}
+	return '2'
+}
+
+// responsibility converts the 7XX block. The first 700, 710 or 720 becomes
+// the main entry, all others added entries.
+func (c *converter) responsibility(df *marc21.DataField) {
+	var kind byte
+	switch df.Tag[1] {
+	case '0':
+		kind = 'p'
+	case '1':
+		kind = 'c'
+	case '2':
+		kind = 'f'
+	}
+	if kind == 0 || df.Tag[2] > '2' {
+		c.report.Unmapped = append(c.report.Unmapped, df)
+		return
+	}
+	tag := "700"
+	if kind == 'c' {
+		tag = "710"
+		if df.Ind1 == '1' {
+			tag = "711"
+		}
+	}
+	if df.Tag[2] == '0' && !c.mainEntry {
+		tag = "1" + tag[1:]
+		c.mainEntry = true
+	}
+	out := &marc21.DataField{Tag: tag, Ind1: nameIndicator(df, kind), Ind2: ' '}
+	c.name(df, out, kind, map[byte]byte{'t': 't'})
+	if len(out.SubFields) > 0 {
+		c.out.AddField(out)
+	}
+}
+
+// subject converts the subject access fields of the 6XX block. Subjects
+// without a source in $2 are marked as unspecified source.
+func (c *converter) subject(df *marc21.DataField) {
+	out := &marc21.DataField{Tag: subjects[df.Tag], Ind1: ' ', Ind2: '4'}
+	switch df.Tag {
+	case "600", "604":
+		out.Ind1 = nameIndicator(df, 'p')
+		c.name(df, out, 'p', mergeCodes(subdivisions, map[byte]byte{'t': 't'}))
+	case "601":
+		out.Ind1 = nameIndicator(df, 'c')
+		c.name(df, out, 'c', subdivisions)
+	case "602":
+		out.Ind1 = '3'
+		c.name(df, out, 'f', subdivisions)
+	default:
+		if df.Tag == "605" {
+			out.Ind1, out.Ind2 = '0', '4'
+		}
+		for _, sf := range df.SubFields {
+			if sf.Code == 'a' {
+				add(out, 'a', sf.Value)
+			} else if code, ok := subdivisions[sf.Code]; ok {
+				add(out, code, sf.Value)
+			} else {
+				c.unmappedSubfield(df.Tag, sf.Code)
+			}
+		}
+	}
+	if source := first(out, '2'); source != "" {
+		if source == "lcsh" {
+			out.Ind2 = '0'
+			out.SubFields = removeCode(out.SubFields, '2')
+		} else {
+			out.Ind2 = '7'
+		}
+	}
+	if len(out.SubFields) > 0 {
+		c.out.AddField(out)
+	}
+}
+
+// mergeCodes returns a new mapping containing the entries of a and b.
+func mergeCodes(a, b map[byte]byte) map[byte]byte {
+	m := make(map[byte]byte, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// removeCode returns the subfields without those with the given code.
+func removeCode(subfields []*marc21.SubField, code byte) []*marc21.SubField {
+	var out []*marc21.SubField
+	for _, sf := range subfields {
+		if sf.Code != code {
+			out = append(out, sf)
+		}
+	}
+	return out
+}
+
+// f040Field returns the cataloging source field, creating it if needed.
+func (c *converter) f040Field() *marc21.DataField {
+	if c.f040 == nil {
+		c.f040 = &marc21.DataField{Tag: "040", Ind1: ' ', Ind2: ' '}
+	}
+	return c.f040
+}
+
+// source converts the originating source (801) into 040. The second
+// indicator tells the function of the agency.
+func (c *converter) source(df *marc21.DataField) {
+	code := map[byte]byte{'0': 'a', '1': 'c', '2': 'd', '3': 'd'}[df.Ind2]
+	agency := first(df, 'b')
+	if code == 0 || agency == "" {
+		c.report.Unmapped = append(c.report.Unmapped, df)
+		return
+	}
+	f := c.f040Field()
+	if code == 'a' && first(f, 'a') != "" {
+		code = 'd'
+	}
+	f.SubFields = append(f.SubFields, &marc21.SubField{Code: code, Value: agency})
+}
+
+// finish adds the fields built from several UNIMARC fields and sorts the
+// fields by tag.
+func (c *converter) finish() {
+	c.out.AddField(&marc21.ControlField{Tag: "008", Data: string(c.f008)})
+	if c.f040 != nil {
+		order := map[byte]int{'a': 0, 'b': 1, 'c': 2, 'd': 3}
+		sort.SliceStable(c.f040.SubFields, func(i, j int) bool {
+			return order[c.f040.SubFields[i].Code] < order[c.f040.SubFields[j].Code]
+		})
+		c.out.AddField(c.f040)
+	}
+	if c.mainEntry {
+		for _, f := range c.out.GetFields("245") {
+			f.(*marc21.DataField).Ind1 = '1'
+		}
+	}
+	sort.SliceStable(c.out.Fields, func(i, j int) bool {
+		return c.out.Fields[i].GetTag() < c.out.Fields[j].GetTag()
+	})
+}
@@ -0,0 +1,146 @@
+package unimarc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miku/marc21"
+)
+
+func df(tag string, ind1, ind2 byte, kv ...string) *marc21.DataField {
+	f := &marc21.DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for _, s := range kv {
+		f.SubFields = append(f.SubFields, &marc21.SubField{Code: s[0], Value: s[1:]})
+	}
+	return f
+}
+
+func testRecord() *marc21.Record {
+	record := &marc21.Record{Leader: &marc21.Leader{
+		Status: 'n', Type: 'a', ImplementationDefined: [5]byte{'m', '0', ' ', ' ', ' '},
+		IndicatorCount: 2, SubfieldCodeLength: 2, LengthOfLength: 4, LengthOfStartPos: 5,
+	}}
+	for _, f := range []marc21.Field{
+		&marc21.ControlField{Tag: "001", Data: "FRBNF123"},
+		df("010", ' ', ' ', "a2-07-036822-X", "bbr.", "dEUR 7,50"),
+		df("100", ' ', ' ', "a20010315d1999    m  y0frey50      ba"),
+		df("101", '1', ' ', "afre", "ceng"),
+		df("200", '1', ' ', "a\u0098Le \u009cpetit prince", "eroman", "fAntoine de Saint-Exupéry", "gtrad. par X", "zfre"),
+		df("205", ' ', ' ', "a2e éd.", "brev. et augm.", "fpréf. de Y", "fill. de Z"),
+		df("210", ' ', ' ', "aParis", "cGallimard", "d1999"),
+		df("215", ' ', ' ', "a93 p.", "cill.", "d18 cm"),
+		df("454", ' ', '1', "tThe little prince"),
+		df("606", ' ', ' ', "aContes", "yFrance", "2rameau"),
+		df("606", ' ', ' ', "aFairy tales", "2lcsh"),
+		df("700", ' ', '1', "aSaint-Exupéry", "bAntoine de", "f1900-1944", "4070"),
+		df("702", ' ', '1', "aDoe", "bJohn", "4730"),
+		df("801", ' ', '0', "aFR", "bBnF", "c20010315"),
+		df("999", ' ', ' ', "alocal"),
+	} {
+		record.AddField(f)
+	}
+	return record
+}
+
+func TestConvert(t *testing.T) {
+	out, report := Convert(testRecord())
+	var cases = []struct {
+		tag string
+		exp string
+	}{
+		{"001", "001 FRBNF123"},
+		{"008", "008 010315s1999    xx                  fre d"},
+		{"020", "020 [  ] [(a) 2-07-036822-X], [(q) br.], [(c) EUR 7,50]"},
+		{"040", "040 [  ] [(a) BnF], [(b) fre]"},
+		{"041", "041 [1 ] [(a) fre], [(h) eng]"},
+		{"100", "100 [1 ] [(a) Saint-Exupéry, Antoine de], [(d) 1900-1944], [(4) aut]"},
+		{"245", "245 [13] [(a) Le petit prince :], [(b) roman /], [(c) Antoine de Saint-Exupéry ; trad. par X]"},
+		{"250", "250 [  ] [(a) 2e éd., rev. et augm. /], [(b) préf. de Y ; ill. de Z]"},
+		{"260", "260 [  ] [(a) Paris :], [(b) Gallimard,], [(c) 1999]"},
+		{"300", "300 [  ] [(a) 93 p. :], [(b) ill. ;], [(c) 18 cm]"},
+		{"650", "650 [ 7] [(a) Contes], [(z) France], [(2) rameau]\n650 [ 0] [(a) Fairy tales]"},
+		{"700", "700 [1 ] [(a) Doe, John], [(4) trl]"},
+		{"999", "999 [  ] [(a) local]"},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, f := range out.GetFields(c.tag) {
+			got = append(got, f.String())
+		}
+		if strings.Join(got, "\n") != c.exp {
+			t.Errorf("%s, got %q, want %q", c.tag, strings.Join(got, "\n"), c.exp)
+		}
+	}
+	if out.Leader.String() != "00000nam a2200000 i 4500" {
+		t.Errorf("leader, got %q", out.Leader.String())
+	}
+	if len(report.Unmapped) != 1 || report.Unmapped[0].GetTag() != "454" {
+		t.Errorf("unmapped, got %v, want 454", report.Unmapped)
+	}
+	if strings.Join(report.UnmappedSubfields, ",") != "200$z" {
+		t.Errorf("unmapped subfields, got %v, want 200$z", report.UnmappedSubfields)
+	}
+	for i := 1; i < len(out.Fields); i++ {
+		if out.Fields[i-1].GetTag() > out.Fields[i].GetTag() {
+			t.Errorf("fields not sorted: %s before %s", out.Fields[i-1].GetTag(), out.Fields[i].GetTag())
+		}
+	}
+}
+
+func TestConvertCharacterSet(t *testing.T) {
+	record := &marc21.Record{}
+	record.AddField(df("100", ' ', ' ', "a20010315d1999    m  y0frey0103    ba"))
+	out, report := Convert(record)
+	if out.Leader.CharacterEncoding != ' ' {
+		t.Errorf("character encoding, got %q, want ' '", out.Leader.CharacterEncoding)
+	}
+	if len(report.Notes) != 1 || report.Empty() {
+		t.Errorf("notes, got %v, want a single note", report.Notes)
+	}
+}
+
+func TestConvertRepeatedSubfields(t *testing.T) {
+	var cases = []struct {
+		field *marc21.DataField
+		exp   string
+		lost  string
+	}{
+		{
+			df("200", '1', ' ', "aTitle one", "aTitle two", "esub one", "esub two", "fA", "fB"),
+			"245 [00] [(a) Title one ; Title two :], [(b) sub one : sub two /], [(c) A ; B]",
+			"",
+		},
+		{
+			df("200", '1', ' ', "aTitle one", "esub one", "aTitle two", "esub two", "hPart 1", "hPart 2"),
+			"245 [00] [(a) Title one ; Title two :], [(b) sub one : sub two.], [(n) Part 1.], [(n) Part 2]",
+			"",
+		},
+		{
+			df("200", '1', ' ', "aTitle", "btext", "bsound"),
+			"245 [00] [(a) Title], [(h) [text]]",
+			"200$b",
+		},
+		{
+			df("205", ' ', ' ', "a2e éd.", "fpréf. de Y", "brev."),
+			"250 [  ] [(a) 2e éd., rev. /], [(b) préf. de Y]",
+			"",
+		},
+	}
+	for _, c := range cases {
+		record := &marc21.Record{}
+		record.AddField(c.field)
+		out, report := Convert(record)
+		var got []string
+		for _, f := range out.Fields {
+			if f.GetTag() != "008" {
+				got = append(got, f.String())
+			}
+		}
+		if strings.Join(got, "\n") != c.exp {
+			t.Errorf("%s, got %q, want %q", c.field, strings.Join(got, "\n"), c.exp)
+		}
+		if strings.Join(report.UnmappedSubfields, ",") != c.lost {
+			t.Errorf("%s, unmapped subfields, got %v, want %q", c.field, report.UnmappedSubfields, c.lost)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package marc21
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxRecordLength is the largest record length that fits into the five digit
+// leader field.
+const maxRecordLength = 99999
+
+// Writer writes records in binary ISO 2709 format.
+type Writer struct {
+	// MARC8, if not nil, is used to convert UTF-8 records to MARC-8 before
+	// they are written. The records passed to Write are not modified.
+	MARC8 *MARC8Encoder
+	// OnLossy, if not nil, is called with the fields of a record that could
+	// not be converted to MARC-8 without loss.
+	OnLossy func(record *Record, fields []Field)
+	// IsControlField, if not nil, is checked against the type of each
+	// field, so that records can be read back with the same predicate.
+	IsControlField func(tag string) bool
+	// Oversize decides what happens to records, that are too long for the
+	// binary format or have fields, that are. By default, Write returns an
+	// error.
+	Oversize Oversize
+	// Priority, if not nil, returns the priority of a field for
+	// OversizeDrop. Fields of the lowest priority are dropped first, the
+	// last of them first; fields with a negative priority are never
+	// dropped. By default, DefaultPriority is used.
+	Priority func(f Field) int
+	// OnDrop, if not nil, is called with the fields dropped from a record
+	// with OversizeDrop.
+	OnDrop func(record *Record, dropped []Field)
+
+	w io.Writer
+}
+
+// NewWriter returns a new writer that writes binary MARC to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write encodes a single record and writes it to the underlying writer.
+func (w *Writer) Write(record *Record) error {
+	for _, f := range record.Fields {
+		if err := checkFieldKind(f, w.IsControlField); err != nil {
+			return err
+		}
+	}
+	if w.MARC8 != nil && (record.Leader == nil || record.Leader.CharacterEncoding == 'a') {
+		encoded, lossy := w.MARC8.encodeRecord(record)
+		if len(lossy) > 0 && w.OnLossy != nil {
+			w.OnLossy(record, lossy)
+		}
+		record = encoded
+	}
+	if w.Oversize != OversizeError {
+		var err error
+		if record, err = w.fit(record); err != nil {
+			return err
+		}
+	}
+	b, err := record.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(b)
+	return err
+}
+
+// defaultLeader returns the leader used for records that do not have one.
+func defaultLeader() *Leader {
+	return &Leader{
+		Status:                'n',
+		Type:                  'a',
+		ImplementationDefined: [5]byte{'m', ' ', ' ', ' ', ' '},
+		CharacterEncoding:     'a',
+		IndicatorCount:        2,
+		SubfieldCodeLength:    2,
+		LengthOfLength:        4,
+		LengthOfStartPos:      5,
+	}
+}
+
+// MarshalBinary encodes the record in binary ISO 2709 format. The directory
+// is built from the fields of the record, the record length and base address
+// of the leader are recomputed; the leader of the record itself is not
+// modified. The indicator count and entry map of the leader are honoured,
+// an implementation-defined part of the directory entries is filled with
+// zeros. A record read with KeepRaw, that is not dirty, is returned as read;
+// otherwise its unchanged fields are, see Record.Dirty.
+func (record *Record) MarshalBinary() ([]byte, error) {
+	if !record.Dirty() {
+		return []byte(record.raw), nil
+	}
+	leader := record.Leader
+	if leader == nil {
+		leader = defaultLeader()
+	}
+	indicators, length, start, impl := leader.entryMap()
+	if indicators > 2 {
+		return nil, fmt.Errorf("unsupported indicator count %d", indicators)
+	}
+	maxLength, maxStart := maxDigits(length), maxDigits(start)
+	var dir, data bytes.Buffer
+	for _, f := range record.Fields {
+		tag := f.GetTag()
+		if len(tag) != 3 {
+			return nil, fmt.Errorf("invalid tag %q, expected 3 bytes", tag)
+		}
+		pos := data.Len()
+		switch field := f.(type) {
+		case *ControlField:
+			if raw := rawField(f, indicators); raw != "" {
+				data.WriteString(raw)
+				break
+			}
+			data.WriteString(field.Data)
+			data.WriteByte(RS)
+		case *DataField:
+			if raw := rawField(f, indicators); raw != "" {
+				data.WriteString(raw)
+				break
+			}
+			data.Write([]byte{field.Ind1, field.Ind2}[:indicators])
+			for _, sf := range field.SubFields {
+				data.WriteByte(DELIM)
+				data.WriteByte(sf.Code)
+				data.WriteString(sf.Value)
+			}
+			data.WriteByte(RS)
+		default:
+			return nil, fmt.Errorf("unsupported field type %T", f)
+		}
+		n := data.Len() - pos
+		if n > maxLength {
+			return nil, fmt.Errorf("field %s too long: %d bytes", tag, n)
+		}
+		if pos > maxStart {
+			return nil, fmt.Errorf("field %s starts beyond position %d", tag, maxStart)
+		}
+		fmt.Fprintf(&dir, "%s%0*d%0*d%s", tag, length, n, start, pos, strings.Repeat("0", impl))
+	}
+	dir.WriteByte(RS)
+	data.WriteByte(RT)
+
+	out := *leader
+	out.IndicatorCount, out.LengthOfLength, out.LengthOfStartPos = indicators, length, start
+	if out.SubfieldCodeLength == 0 {
+		out.SubfieldCodeLength = 2
+	}
+	out.BaseAddress = 24 + dir.Len()
+	out.Length = out.BaseAddress + data.Len()
+	if out.Length > maxRecordLength {
+		return nil, fmt.Errorf("record too long: %d bytes", out.Length)
+	}
+	buf := make([]byte, 0, out.Length)
+	buf = append(buf, out.Bytes()...)
+	buf = append(buf, dir.Bytes()...)
+	buf = append(buf, data.Bytes()...)
+	return buf, nil
+}
+
+// maxDigits returns the largest number with the given number of digits.
+func maxDigits(n int) int {
+	max := 1
+	for i := 0; i < n; i++ {
+		max *= 10
+	}
+	return max - 1
+}
@@ -0,0 +1,202 @@
+package marc21
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestMarshalBinaryRoundtrip reads all test records and checks, that encoding
+// them yields the original bytes. Leader positions 22-23 are always written
+// as "00", so they are not compared.
+func TestMarshalBinaryRoundtrip(t *testing.T) {
+	b, err := ioutil.ReadFile("fixtures/test.mrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(b)
+	offset := 0
+	for {
+		record, err := ReadRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := record.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := b[offset : offset+record.Leader.Length]
+		if !bytes.Equal(out[:22], orig[:22]) || !bytes.Equal(out[24:], orig[24:]) {
+			t.Errorf("record at offset %d: got %q, want %q", offset, out, orig)
+		}
+		offset += record.Leader.Length
+	}
+	if offset != len(b) {
+		t.Errorf("roundtrip: got %d bytes, want %d bytes", offset, len(b))
+	}
+}
+
+// TestMarshalBinaryRecomputesLeader checks length and base address of an
+// edited record.
+func TestMarshalBinaryRecomputesLeader(t *testing.T) {
+	record := &Record{}
+	record.AddField(&ControlField{Tag: "001", Data: "12345"})
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{
+		{Code: 'a', Value: "Title"},
+	}})
+	b, err := record.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadRecord(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Leader.Length != len(b) {
+		t.Errorf("Leader.Length, got %v, want %v", parsed.Leader.Length, len(b))
+	}
+	if parsed.Leader.BaseAddress != 24+2*12+1 {
+		t.Errorf("Leader.BaseAddress, got %v, want %v", parsed.Leader.BaseAddress, 24+2*12+1)
+	}
+	if record.Leader != nil {
+		t.Errorf("record.Leader, got %v, want nil", record.Leader)
+	}
+	if parsed.String() != record.String() {
+		t.Errorf("String, got %v, want %v", parsed.String(), record.String())
+	}
+}
+
+func TestMarshalBinaryInvalidTag(t *testing.T) {
+	record := &Record{}
+	record.AddField(&ControlField{Tag: "01", Data: "x"})
+	if _, err := record.MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary, got %v, want some error", err)
+	}
+}
+
+// oversizeRecord returns a record with a contents note of more than 9999
+// bytes, split over many subfields, followed by a single subfield of more
+// than 9999 bytes, and n electronic locations of 5000 bytes each.
+func oversizeRecord(n int) *Record {
+	record := &Record{}
+	record.AddField(&ControlField{Tag: "001", Data: "1"})
+	record.AddField(&DataField{Tag: "245", Ind1: '1', Ind2: '0', SubFields: []*SubField{{Code: 'a', Value: "Title"}}})
+	note := &DataField{Tag: "505", Ind1: '0', Ind2: ' '}
+	for i := 0; i < 150; i++ {
+		note.SubFields = append(note.SubFields, &SubField{Code: 't', Value: strings.Repeat("x", 98)})
+	}
+	note.SubFields = append(note.SubFields, &SubField{Code: 'g', Value: strings.Repeat("é", 6000)})
+	record.AddField(note)
+	for i := 0; i < n; i++ {
+		record.AddField(&DataField{Tag: "856", Ind1: '4', Ind2: '0', SubFields: []*SubField{
+			{Code: 'u', Value: strings.Repeat("u", 5000)},
+		}})
+	}
+	return record
+}
+
+func TestWriterOversize(t *testing.T) {
+	record := oversizeRecord(0)
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).Write(record); err == nil {
+		t.Errorf("Write, got nil, want some error")
+	}
+
+	w := NewWriter(&buf)
+	w.Oversize = OversizeSplit
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadRecord(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := parsed.GetFields("505")
+	if len(notes) < 2 {
+		t.Fatalf("505, got %d fields, want more than one", len(notes))
+	}
+	var got, want string
+	for _, f := range notes {
+		df := f.(*DataField)
+		if df.Ind1 != '0' || df.Ind2 != ' ' {
+			t.Errorf("indicators, got %q %q, want '0' ' '", df.Ind1, df.Ind2)
+		}
+		for _, sf := range df.SubFields {
+			if !utf8.ValidString(sf.Value) {
+				t.Errorf("subfield $%c split within a character", sf.Code)
+			}
+			got += sf.Value
+		}
+	}
+	for _, sf := range record.GetSubFields("505", 't') {
+		want += sf.Value
+	}
+	for _, sf := range record.GetSubFields("505", 'g') {
+		want += sf.Value
+	}
+	if got != want {
+		t.Errorf("505, got %d bytes, want %d bytes", len(got), len(want))
+	}
+	if len(record.GetFields("505")) != 1 {
+		t.Errorf("Write modified the record")
+	}
+
+	w = NewWriter(&buf)
+	w.Oversize = OversizeDrop
+	var dropped []Field
+	w.OnDrop = func(r *Record, fields []Field) {
+		dropped = fields
+	}
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err = ReadRecord(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.GetFields("505")) != 0 || len(parsed.GetFields("245")) != 1 {
+		t.Errorf("fields, got %v", parsed)
+	}
+	if len(dropped) != 1 || dropped[0].GetTag() != "505" {
+		t.Errorf("dropped, got %v, want the 505", dropped)
+	}
+}
+
+func TestWriterOversizeRecord(t *testing.T) {
+	record := oversizeRecord(25)
+	record.Fields = append(record.Fields[:2], record.Fields[3:]...)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Oversize = OversizeSplit
+	if err := w.Write(record); err == nil {
+		t.Errorf("Write with OversizeSplit, got nil, want some error")
+	}
+
+	w.Oversize = OversizeDrop
+	var dropped []Field
+	w.OnDrop = func(r *Record, fields []Field) {
+		dropped = fields
+	}
+	if err := w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ReadRecord(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Leader.Length > maxRecordLength {
+		t.Errorf("Leader.Length, got %d, want at most %d", parsed.Leader.Length, maxRecordLength)
+	}
+	if n := len(parsed.GetFields("856")); n != 19 || len(dropped) != 6 {
+		t.Errorf("856, got %d fields and %d dropped, want 19 and 6", n, len(dropped))
+	}
+	if len(dropped) > 0 && dropped[len(dropped)-1] != record.Fields[len(record.Fields)-1] {
+		t.Errorf("dropped, want the last fields of the record")
+	}
+}
@@ -0,0 +1,237 @@
+package marc21
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Namespace is the MARCXML namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+// isMARCXML returns true, if the name is a MARCXML element with the given
+// local name. Elements without a namespace are accepted as well.
+func isMARCXML(name xml.Name, local string) bool {
+	return name.Local == local && (name.Space == "" || name.Space == Namespace)
+}
+
+// attr returns the value of the attribute with the given local name.
+func attr(se xml.StartElement, local string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// indicator returns the first byte of an indicator attribute, or a blank.
+func indicator(s string) byte {
+	if len(s) == 0 {
+		return ' '
+	}
+	return s[0]
+}
+
+// UnmarshalXML decodes a MARCXML record element. A record without leader
+// is an error.
+func (record *Record) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	record.Leader = nil
+	record.Fields = make([]Field, 0, 8)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case isMARCXML(t.Name, "leader"):
+				var s string
+				if err := d.DecodeElement(&s, &t); err != nil {
+					return err
+				}
+				if record.Leader, err = parseLeaderString(s); err != nil {
+					return err
+				}
+			case isMARCXML(t.Name, "controlfield"):
+				var s string
+				if err := d.DecodeElement(&s, &t); err != nil {
+					return err
+				}
+				record.Fields = append(record.Fields, &ControlField{Tag: attr(t, "tag"), Data: s})
+			case isMARCXML(t.Name, "datafield"):
+				df := &DataField{}
+				if err := d.DecodeElement(df, &t); err != nil {
+					return err
+				}
+				record.Fields = append(record.Fields, df)
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if record.Leader == nil {
+				return errors.New("record without leader")
+			}
+			return nil
+		}
+	}
+}
+
+// UnmarshalXML decodes a MARCXML datafield element.
+func (df *DataField) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	df.Tag = attr(start, "tag")
+	df.Ind1 = indicator(attr(start, "ind1"))
+	df.Ind2 = indicator(attr(start, "ind2"))
+	df.SubFields = make([]*SubField, 0, 1)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !isMARCXML(t.Name, "subfield") {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			sf := &SubField{}
+			if err := d.DecodeElement(sf, &t); err != nil {
+				return err
+			}
+			df.SubFields = append(df.SubFields, sf)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// UnmarshalXML decodes a MARCXML subfield element.
+func (sf *SubField) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	code := attr(start, "code")
+	if len(code) != 1 {
+		return fmt.Errorf("invalid subfield code %q", code)
+	}
+	sf.Code = code[0]
+	return d.DecodeElement(&sf.Value, &start)
+}
+
+// XMLReader reads records from a MARCXML document. Records are decoded one
+// at a time, so large collections do not need to be held in memory. Records
+// may appear anywhere in the document, with or without the MARCXML
+// namespace.
+type XMLReader struct {
+	dec    *xml.Decoder
+	record *Record
+	err    error
+	count  int
+}
+
+// NewXMLReader returns a new XMLReader that reads from r.
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{dec: xml.NewDecoder(r)}
+}
+
+// Next returns the next record from the document. It returns io.EOF, if
+// there are no more records.
+func (r *XMLReader) Next() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			r.err = err
+			return nil, err
+		}
+		se, ok := token.(xml.StartElement)
+		if !ok || !isMARCXML(se.Name, "record") {
+			continue
+		}
+		record := &Record{}
+		if err := r.dec.DecodeElement(record, &se); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			r.err = err
+			return nil, err
+		}
+		r.count++
+		r.record = record
+		return record, nil
+	}
+}
+
+// Scan advances the reader to the next record, which will then be available
+// through the Record method. It returns false when the document is exhausted
+// or an error occurred.
+func (r *XMLReader) Scan() bool {
+	_, err := r.Next()
+	return err == nil
+}
+
+// Record returns the most recent record read by a call to Scan or Next.
+func (r *XMLReader) Record() *Record {
+	return r.record
+}
+
+// Err returns the first non-EOF error that was encountered by the reader.
+func (r *XMLReader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Count returns the number of records read so far.
+func (r *XMLReader) Count() int {
+	return r.count
+}
+
+// XMLWriter writes records as a MARCXML collection. The collection is
+// started with the first record and ended by Close, which must be called
+// after the last record. If no record was written, Close writes an empty
+// collection.
+type XMLWriter struct {
+	w       io.Writer
+	started bool
+}
+
+// NewXMLWriter returns a new XMLWriter that writes to w.
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	return &XMLWriter{w: w}
+}
+
+// start writes the XML declaration and the start of the collection once.
+func (w *XMLWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	_, err := io.WriteString(w.w, `<?xml version="1.0" encoding="utf-8" ?>`+
+		`<collection xmlns="`+Namespace+`">`)
+	return err
+}
+
+// Write writes a single record.
+func (w *XMLWriter) Write(record *Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	_, err := record.WriteTo(w.w)
+	return err
+}
+
+// Close ends the collection. It does not close the underlying writer.
+func (w *XMLWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "</collection>\n")
+	return err
+}
@@ -0,0 +1,155 @@
+package marc21
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestXMLReaderRoundtrip(t *testing.T) {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	var records []*Record
+	var buf bytes.Buffer
+	buf.WriteString(`<collection xmlns="http://www.loc.gov/MARC21/slim">`)
+	r := NewReader(data)
+	for r.Scan() {
+		records = append(records, r.Record())
+		if _, err := r.Record().WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString(`</collection>`)
+
+	xr := NewXMLReader(&buf)
+	for i := 0; ; i++ {
+		record, err := xr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.String() != records[i].String() {
+			t.Errorf("record %d, got %v, want %v", i, record, records[i])
+		}
+		if record.Leader.String() != records[i].Leader.String() {
+			t.Errorf("record %d, got leader %v, want %v", i, record.Leader, records[i].Leader)
+		}
+	}
+	if xr.Count() != len(records) {
+		t.Errorf("Count, got %v, want %v", xr.Count(), len(records))
+	}
+}
+
+func TestXMLReaderNamespaces(t *testing.T) {
+	var cases = []string{
+		`<collection><record><leader>     nam a22     1i 4500</leader>
+		<controlfield tag="001">123</controlfield>
+		<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Title</subfield></datafield>
+		</record></collection>`,
+		`<?xml version="1.0"?><marc:collection xmlns:marc="http://www.loc.gov/MARC21/slim">
+		<marc:record><marc:leader>00000nam a2200000 i 4500</marc:leader>
+		<marc:controlfield tag="001">123</marc:controlfield>
+		<marc:datafield tag="245" ind1="1" ind2="0"><marc:subfield code="a">Title</marc:subfield></marc:datafield>
+		</marc:record></marc:collection>`,
+		`<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/"><metadata>
+		<record xmlns="http://www.loc.gov/MARC21/slim"><leader>00000nam a2200000 i 4500</leader>
+		<controlfield tag="001">123</controlfield>
+		<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Title</subfield></datafield>
+		</record></metadata></OAI-PMH>`,
+	}
+	for _, c := range cases {
+		r := NewXMLReader(strings.NewReader(c))
+		var records []*Record
+		for r.Scan() {
+			records = append(records, r.Record())
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("records, got %d, want %d", len(records), 1)
+		}
+		if records[0].Identifier() != "123" {
+			t.Errorf("Identifier, got %v, want %v", records[0].Identifier(), "123")
+		}
+		if v := records[0].GetSubFields("245", 'a'); len(v) != 1 || v[0].Value != "Title" {
+			t.Errorf("GetSubFields, got %v, want %v", v, "Title")
+		}
+	}
+}
+
+func TestXMLReaderInvalid(t *testing.T) {
+	r := NewXMLReader(strings.NewReader(`<collection><record><leader>short</leader></record></collection>`))
+	if r.Scan() {
+		t.Errorf("Scan, got true, want false")
+	}
+	if r.Err() == nil {
+		t.Errorf("Err, got nil, want some error")
+	}
+}
+
+func TestUnmarshalXMLWithoutLeader(t *testing.T) {
+	var record Record
+	err := xml.Unmarshal([]byte(`<record><controlfield tag="001">1</controlfield></record>`), &record)
+	if err == nil {
+		t.Errorf("Unmarshal, got nil error and leader %v, want some error", record.Leader)
+	}
+	r := NewXMLReader(strings.NewReader(`<collection><record></record></collection>`))
+	if r.Scan() || r.Err() == nil {
+		t.Errorf("XMLReader, got no error, want some error")
+	}
+}
+
+func TestXMLWriter(t *testing.T) {
+	data := openTestMARC(t)
+	defer data.Close()
+
+	var records []*Record
+	var buf bytes.Buffer
+	w := NewXMLWriter(&buf)
+	r := NewReader(data)
+	for r.Scan() {
+		records = append(records, r.Record())
+		if err := w.Write(r.Record()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	xr := NewXMLReader(&buf)
+	for i := 0; xr.Scan(); i++ {
+		if xr.Record().String() != records[i].String() {
+			t.Errorf("record %d, got %v, want %v", i, xr.Record(), records[i])
+		}
+	}
+	if err := xr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if xr.Count() != len(records) {
+		t.Errorf("Count, got %v, want %v", xr.Count(), len(records))
+	}
+}
+
+func TestXMLWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewXMLWriter(&buf).Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="utf-8" ?><collection xmlns="http://www.loc.gov/MARC21/slim"></collection>` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}